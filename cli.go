@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const cliUsage = `Usage: godcr --nogui <command> [arguments]
+
+Commands:
+  wallets                                        list wallets, accounts and balances
+  sync                                           sync the wallets with the network
+  address <wallet-id> [account]                  generate a new receiving address
+  send <wallet-id> <account> <address> <amount>  sync and send an amount of DCR
+  buytickets <wallet-id> <account> <count> <vsp> sync and purchase tickets through a VSP
+
+Passphrases are read from standard input, one per line. The startup
+passphrase is read first if one is set. Commands that sync fail if the
+sync does not complete in 10 minutes.`
+
+// cliSyncTimeout is how long a command waits for the wallets to sync, as the
+// sync never completes without peers.
+const cliSyncTimeout = 10 * time.Minute
+
+// cliWallet is a wallet in the output of the wallets command.
+type cliWallet struct {
+	ID              int
+	Name            string
+	Balance         string
+	Spendable       string
+	BestBlockHeight int32
+	IsWatchingOnly  bool
+	Accounts        []cliAccount
+}
+
+// cliAccount is an account in the output of the wallets command.
+type cliAccount struct {
+	Number         int32
+	Name           string
+	Balance        string
+	Spendable      string
+	CurrentAddress string
+}
+
+// cli runs a single wallet command without the GUI. Results are written to
+// stdout as JSON so the command can be driven by scripts.
+type cli struct {
+	wal    *wallet.Wallet
+	multi  *dcrlibwallet.MultiWallet
+	stdin  *bufio.Reader
+	synced chan error
+}
+
+// runCLI loads the wallets in the app directory, runs the command in args
+// and prints its result. Errors are printed as a JSON object with an Error
+// field.
+func runCLI(wal *wallet.Wallet, args []string) error {
+	if len(args) > 0 && args[0] == "help" {
+		fmt.Println(cliUsage)
+		return nil
+	}
+
+	c := &cli{
+		wal:    wal,
+		stdin:  bufio.NewReader(os.Stdin),
+		synced: make(chan error, 1),
+	}
+
+	result, err := c.run(args)
+	if err != nil {
+		result = struct{ Error string }{err.Error()}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if encErr := enc.Encode(result); encErr != nil {
+		return encErr
+	}
+	return err
+}
+
+func (c *cli) run(args []string) (interface{}, error) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, cliUsage)
+		return nil, errors.New("no command given")
+	}
+
+	if err := c.openWallets(); err != nil {
+		return nil, err
+	}
+	defer c.wal.Shutdown()
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "wallets":
+		return c.wallets()
+	case "sync":
+		return c.sync()
+	case "address":
+		return c.address(args)
+	case "send":
+		return c.send(args)
+	case "buytickets":
+		return c.buyTickets(args)
+	}
+
+	fmt.Fprintln(os.Stderr, cliUsage)
+	return nil, fmt.Errorf("unknown command %q", command)
+}
+
+// openWallets opens the wallets and subscribes to the sync notifications
+// that end a sync.
+func (c *cli) openWallets() error {
+	err := c.wal.InitMultiWallet()
+	if err != nil {
+		return err
+	}
+	c.multi = c.wal.GetMultiWallet()
+
+	var startupPass []byte
+	if c.multi.IsStartupSecuritySet() {
+		pass, err := c.readPassphrase("Startup passphrase")
+		if err != nil {
+			return err
+		}
+		startupPass = []byte(pass)
+	}
+
+	err = c.multi.OpenWallets(startupPass)
+	if err != nil {
+		return err
+	}
+
+	c.wal.Events().Subscribe(wallet.EventHandlers{
+		OnSync: func(update wallet.SyncStatusUpdate) {
+			switch update.Stage {
+			case wallet.SyncCompleted:
+				c.syncDone(nil)
+			case wallet.SyncCanceled:
+				c.syncDone(errors.New("sync canceled"))
+			}
+		},
+	})
+
+	return c.wal.SetupListeners()
+}
+
+func (c *cli) syncDone(err error) {
+	select {
+	case c.synced <- err:
+	default:
+	}
+}
+
+func (c *cli) readPassphrase(prompt string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	pass, err := c.stdin.ReadString('\n')
+	if err != nil && pass == "" {
+		return "", fmt.Errorf("reading %s: %v", strings.ToLower(prompt), err)
+	}
+	return strings.TrimRight(pass, "\r\n"), nil
+}
+
+func (c *cli) wallets() (interface{}, error) {
+	info, err := c.wal.MultiWalletInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	wallets := make([]cliWallet, len(info.Wallets))
+	for i, w := range info.Wallets {
+		wallets[i] = cliWallet{
+			ID:              w.ID,
+			Name:            w.Name,
+			Balance:         w.Balance,
+			Spendable:       dcrutil.Amount(w.SpendableBalance).String(),
+			BestBlockHeight: w.BestBlockHeight,
+			IsWatchingOnly:  w.IsWatchingOnly,
+		}
+		for _, acct := range w.Accounts {
+			wallets[i].Accounts = append(wallets[i].Accounts, cliAccount{
+				Number:         acct.Number,
+				Name:           acct.Name,
+				Balance:        acct.TotalBalance,
+				Spendable:      dcrutil.Amount(acct.SpendableBalance).String(),
+				CurrentAddress: acct.CurrentAddress,
+			})
+		}
+	}
+
+	return wallets, nil
+}
+
+func (c *cli) sync() (interface{}, error) {
+	err := c.wal.StartSync()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case err = <-c.synced:
+		if err != nil {
+			return nil, err
+		}
+	case <-time.After(cliSyncTimeout):
+		c.multi.CancelSync()
+		return nil, fmt.Errorf("sync did not complete in %s", cliSyncTimeout)
+	}
+
+	best := c.multi.GetBestBlock()
+	return struct {
+		Synced          bool
+		BestBlockHeight int32
+	}{true, best.Height}, nil
+}
+
+func (c *cli) address(args []string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, errors.New("usage: address <wallet-id> [account]")
+	}
+
+	account := "0"
+	if len(args) > 1 {
+		account = args[1]
+	}
+
+	wal, number, err := c.walletAccount(args[0], account)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := wal.NextAddress(number)
+	if err != nil {
+		return nil, err
+	}
+
+	return struct{ Address string }{address}, nil
+}
+
+func (c *cli) send(args []string) (interface{}, error) {
+	if len(args) != 4 {
+		return nil, errors.New("usage: send <wallet-id> <account> <address> <amount>")
+	}
+
+	wal, account, err := c.spendingAccount(args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.multi.IsAddressValid(args[2]) {
+		return nil, errors.New("invalid address")
+	}
+
+	amount, err := strconv.ParseFloat(args[3], 64)
+	if err != nil || amount <= 0 {
+		return nil, errors.New("invalid amount")
+	}
+
+	pass, err := c.readPassphrase("Spending passphrase")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.sync(); err != nil {
+		return nil, err
+	}
+
+	hash, err := c.wal.SendToAddress(wal.ID, account, args[2], dcrlibwallet.AmountAtom(amount), []byte(pass))
+	if err != nil {
+		return nil, err
+	}
+
+	return struct{ Hash string }{hash}, nil
+}
+
+func (c *cli) buyTickets(args []string) (interface{}, error) {
+	if len(args) != 4 {
+		return nil, errors.New("usage: buytickets <wallet-id> <account> <count> <vsp>")
+	}
+
+	wal, account, err := c.spendingAccount(args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(args[2])
+	if err != nil || count <= 0 {
+		return nil, errors.New("invalid ticket count")
+	}
+
+	pass, err := c.readPassphrase("Spending passphrase")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.sync(); err != nil {
+		return nil, err
+	}
+
+	vsp, err := c.multi.NewVSPClient(args[3], wal.ID, uint32(account))
+	if err != nil {
+		return nil, err
+	}
+
+	// the fee is only recorded for the staking analytics, so the tickets
+	// are still bought if it cannot be read.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	feePercentage, err := vsp.PoolFee(ctx)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading the VSP fee: %v\n", err)
+	}
+
+	start := time.Now()
+	err = vsp.PurchaseTickets(int32(count), wallet.TicketExpiry, []byte(pass))
+	if err != nil {
+		return nil, err
+	}
+
+	c.wal.RecordTicketPurchase(wallet.TicketPurchase{
+		WalletID:      wal.ID,
+		VSPHost:       args[3],
+		FeePercentage: feePercentage,
+		Count:         int32(count),
+		Start:         start,
+		End:           time.Now(),
+	})
+
+	return struct{ Purchased int }{count}, nil
+}
+
+func (c *cli) walletAccount(walletID, account string) (*dcrlibwallet.Wallet, int32, error) {
+	id, err := strconv.Atoi(walletID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid wallet id %q", walletID)
+	}
+
+	wal := c.multi.WalletWithID(id)
+	if wal == nil {
+		return nil, 0, wallet.ErrIDNotExist
+	}
+
+	number, err := strconv.ParseInt(account, 10, 32)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid account %q", account)
+	}
+
+	if _, err := wal.GetAccount(int32(number)); err != nil {
+		return nil, 0, err
+	}
+
+	return wal, int32(number), nil
+}
+
+// spendingAccount returns the wallet and account of a command that spends
+// funds. Watch-only wallets cannot sign transactions.
+func (c *cli) spendingAccount(walletID, account string) (*dcrlibwallet.Wallet, int32, error) {
+	wal, number, err := c.walletAccount(walletID, account)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if wal.IsWatchingOnlyWallet() {
+		return nil, 0, errors.New("watch-only wallets cannot spend")
+	}
+
+	return wal, number, nil
+}
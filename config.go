@@ -5,18 +5,21 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/decred/dcrd/dcrutil/v2"
 	"github.com/decred/slog"
 	flags "github.com/jessevdk/go-flags"
 	"github.com/planetdecred/godcr/version"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const (
-	defaultNetwork        = "testnet3"
+	defaultNetwork        = wallet.Testnet3
 	defaultConfigFileName = "godcr.conf"
 	defaultLogFilename    = "godcr.log"
 	defaultLogLevel       = "info"
@@ -30,7 +33,7 @@ var (
 )
 
 type config struct {
-	Network          string `long:"network" description:"Network to use"`
+	Network          string `long:"network" description:"Network to use {mainnet, testnet3}"`
 	HomeDir          string `long:"appdata" description:"Directory where the app configuration file and wallet data is stored"`
 	ConfigFile       string `long:"configfile" description:"Filename of the config file in the app directory"`
 	ShowVersion      bool   `short:"V" long:"version" description:"Display version information and exit"`
@@ -40,14 +43,32 @@ type config struct {
 	Quiet            bool   `short:"q" long:"quiet" description:"Easy way to set debuglevel to error"`
 	SpendUnconfirmed bool   `long:"spendunconfirmed" description:"Allow the multiwallet to use transactions that have not been confirmed"`
 	Profile          int    `long:"profile" description:"Runs local web server for profiling"`
+	RPCPort          int    `long:"rpcport" description:"Runs a localhost JSON-RPC server on the port, requires rpcuser and rpcpass"`
+	RPCUser          string `long:"rpcuser" description:"Username for JSON-RPC connections"`
+	RPCPass          string `long:"rpcpass" default-mask:"-" description:"Password for JSON-RPC connections"`
+	NoGUI            bool   `long:"nogui" description:"Run a wallet command without the GUI and print the result as JSON, see --nogui help"`
+
+	// Args holds the command and arguments run in nogui mode.
+	Args []string `no-flag:"true"`
+
+	RateSources  string        `long:"ratesources" description:"Comma separated list of exchange rate sources queried in order of preference {bittrex, binance, dcrdata, file}"`
+	RateFile     string        `long:"ratefile" description:"JSON file mapping currency codes to the price of one DCR, used by the file rate source"`
+	RateCacheTTL time.Duration `long:"ratecachettl" description:"How long a fetched exchange rate is used before it is fetched again"`
+
+	ExplorerTx       string `long:"explorertx" description:"Block explorer URL of a transaction, {} is replaced by the transaction hash. Defaults to dcrdata on mainnet and testnet"`
+	ExplorerBlock    string `long:"explorerblock" description:"Block explorer URL of a block, {} is replaced by the block height"`
+	ExplorerAddress  string `long:"exploreraddress" description:"Block explorer URL of an address, {} is replaced by the address"`
+	ExplorerProposal string `long:"explorerproposal" description:"URL of a Politeia proposal, {} is replaced by the proposal token"`
 }
 
 var defaultConfig = config{
-	Network:    defaultNetwork,
-	HomeDir:    defaultHomeDir,
-	ConfigFile: defaultConfigFilename,
-	LogDir:     defaultLogDir,
-	DebugLevel: defaultLogLevel,
+	Network:      defaultNetwork,
+	HomeDir:      defaultHomeDir,
+	ConfigFile:   defaultConfigFilename,
+	LogDir:       defaultLogDir,
+	DebugLevel:   defaultLogLevel,
+	RateSources:  strings.Join(wallet.DefaultRateSources, ","),
+	RateCacheTTL: wallet.DefaultRateCacheTTL,
 }
 
 // validLogLevel returns whether or not logLevel is a valid debug log level.
@@ -151,6 +172,9 @@ func loadConfig() (*config, error) {
 		return loadConfigError(flagerr)
 	}
 
+	// Keep stdout for the JSON output of nogui commands.
+	logToStderr = preCfg.NoGUI
+
 	// Show the version and exit if the version flag was specified.
 	appName := filepath.Base(os.Args[0])
 	appName = strings.TrimSuffix(appName, filepath.Ext(appName))
@@ -187,7 +211,7 @@ func loadConfig() (*config, error) {
 			return loadConfigError(err)
 		}
 		// Warn about missing default config file, but continue
-		fmt.Printf("Config file (%s) does not exist. Using defaults.\n",
+		fmt.Fprintf(logOutput(), "Config file (%s) does not exist. Using defaults.\n",
 			preCfg.ConfigFile)
 	} else {
 		// The config file exists, so attempt to parse it.
@@ -204,13 +228,14 @@ func loadConfig() (*config, error) {
 	}
 
 	// Parse command line options again to ensure they take precedence.
-	_, err := parser.Parse()
+	args, err := parser.Parse()
 	if err != nil {
 		if e, ok := err.(*flags.Error); !ok || e.Type != flags.ErrHelp {
 			parser.WriteHelp(os.Stderr)
 		}
 		return loadConfigError(err)
 	}
+	cfg.Args = args
 
 	// Create the home directory if it doesn't already exist.
 	funcName := "loadConfig"
@@ -276,12 +301,101 @@ func loadConfig() (*config, error) {
 		return loadConfigError(err)
 	}
 
+	// Validate the network.
+	network, ok := wallet.Network(cfg.Network)
+	if !ok {
+		err := fmt.Errorf("%s: unknown network %q -- supported networks %v", funcName, cfg.Network, wallet.NetworkNames())
+		fmt.Fprintln(os.Stderr, err)
+		return loadConfigError(err)
+	}
+	if !network.Supported() {
+		err := fmt.Errorf("%s: the wallet backend cannot open %s wallets yet", funcName, cfg.Network)
+		fmt.Fprintln(os.Stderr, err)
+		return loadConfigError(err)
+	}
+
+	if cfg.RPCPort > 0 && (cfg.RPCUser == "" || cfg.RPCPass == "") {
+		err := fmt.Errorf("%s: rpcuser and rpcpass must be set to run the JSON-RPC server", funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return loadConfigError(err)
+	}
+
+	if cfg.RateFile != "" {
+		cfg.RateFile = cleanAndExpandPath(cfg.RateFile)
+	}
+
+	// Validate the exchange rate sources.
+	if _, err := rateSources(&cfg); err != nil {
+		err = fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		return loadConfigError(err)
+	}
+
+	// Validate the block explorer URLs.
+	if err := blockExplorer(&cfg).Validate(); err != nil {
+		err = fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		return loadConfigError(err)
+	}
+
 	log.Debugf("Log folder: %s", cfg.LogDir)
 	log.Debugf("Config file: %s", configFile)
 
 	return &cfg, nil
 }
 
+// rateSources returns the exchange rate sources listed in cfg.RateSources.
+func rateSources(cfg *config) ([]wallet.RateSource, error) {
+	var sources []wallet.RateSource
+	for _, name := range strings.Split(cfg.RateSources, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+
+		source, err := wallet.NewRateSource(name, cfg.RateFile)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// blockExplorer returns the block explorer URLs set in cfg, using the
+// defaults of the network for the ones that are not set.
+func blockExplorer(cfg *config) wallet.BlockExplorer {
+	explorer := wallet.BlockExplorer{
+		Tx:       cfg.ExplorerTx,
+		Block:    cfg.ExplorerBlock,
+		Address:  cfg.ExplorerAddress,
+		Proposal: cfg.ExplorerProposal,
+	}
+	return explorer.Merge(wallet.DefaultBlockExplorer(cfg.Network))
+}
+
+// configSummary returns the options of cfg as "name=value" lines for the
+// diagnostics bundle. Options whose default is masked in the help, such as
+// passwords, are redacted.
+func configSummary(cfg *config) string {
+	var b strings.Builder
+	v := reflect.ValueOf(*cfg)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("long")
+		if name == "" {
+			continue
+		}
+
+		value := fmt.Sprint(v.Field(i).Interface())
+		if field.Tag.Get("default-mask") == "-" && value != "" {
+			value = "<redacted>"
+		}
+		fmt.Fprintf(&b, "%s=%s\n", name, value)
+	}
+	return b.String()
+}
+
 // cleanAndExpandPath expands environment variables and leading ~ in the passed
 // path, cleans the result, and returns it.
 func cleanAndExpandPath(path string) string {
@@ -6,24 +6,38 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/decred/slog"
 	"github.com/jrick/logrotate/rotator"
 	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/rpcserver"
 	"github.com/planetdecred/godcr/ui"
 	"github.com/planetdecred/godcr/ui/page"
 	"github.com/planetdecred/godcr/wallet"
 )
 
+// logToStderr is set in nogui mode, where stdout is reserved for command
+// output.
+var logToStderr bool
+
+// logOutput returns the stream log messages are printed to.
+func logOutput() io.Writer {
+	if logToStderr {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 // logWriter implements an io.Writer that outputs to both standard output and
 // the write-end pipe of an initialized log rotator.
 type logWriter struct{}
 
 // Write writes the data in p to standard out and the log rotator.
 func (l logWriter) Write(p []byte) (n int, err error) {
-	os.Stdout.Write(p)
+	logOutput().Write(p)
 	return logRotator.Write(p)
 }
 
@@ -51,6 +65,7 @@ var (
 	winLog    = backendLog.Logger("UI")
 	dlwlLog   = backendLog.Logger("DLWL")
 	pageLog   = backendLog.Logger("PAGE")
+	rpcsLog   = backendLog.Logger("RPCS")
 )
 
 // Initialize package-global logger variables.
@@ -59,6 +74,7 @@ func init() {
 	ui.UseLogger(winLog)
 	dcrlibwallet.UseLogger(dlwlLog)
 	page.UseLogger(pageLog)
+	rpcserver.UseLogger(rpcsLog)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -68,6 +84,41 @@ var subsystemLoggers = map[string]slog.Logger{
 	"UI":   winLog,
 	"GDCR": log,
 	"PAGE": pageLog,
+	"RPCS": rpcsLog,
+}
+
+// logLevels changes the levels of the subsystem loggers at runtime.
+type logLevels struct{}
+
+// Subsystems returns the supported subsystems in alphabetical order.
+func (logLevels) Subsystems() []string {
+	return supportedSubsystems()
+}
+
+// Level returns the level of subsystemID, e.g. "INF".
+func (logLevels) Level(subsystemID string) string {
+	logger, ok := subsystemLoggers[subsystemID]
+	if !ok {
+		return ""
+	}
+	return logger.Level().String()
+}
+
+// SetLevel validates and sets the level of subsystemID. The level of the
+// dcrlibwallet subsystem also applies to the loggers of its packages, which
+// setLogLevel resets to info.
+func (logLevels) SetLevel(subsystemID, logLevel string) error {
+	if _, exists := subsystemLoggers[subsystemID]; !exists {
+		return fmt.Errorf("the subsystem [%v] is invalid", subsystemID)
+	}
+	if !validLogLevel(logLevel) {
+		return fmt.Errorf("the debug level [%v] is invalid", logLevel)
+	}
+
+	setLogLevel(subsystemID, logLevel)
+	dcrlibwallet.SetLogLevels(dlwlLog.Level().String())
+	log.Infof("Log level of %s set to %s", subsystemID, logLevel)
+	return nil
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
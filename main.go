@@ -11,6 +11,7 @@ import (
 	"gioui.org/app"
 
 	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/rpcserver"
 	"github.com/planetdecred/godcr/ui"
 	_ "github.com/planetdecred/godcr/ui/assets"
 	"github.com/planetdecred/godcr/wallet"
@@ -50,20 +51,54 @@ func main() {
 	}
 
 	logFile := filepath.Join(cfg.LogDir, defaultLogFilename)
-	wal, err := wallet.NewWallet(cfg.HomeDir, cfg.Network, Version, logFile, buildDate, make(chan wallet.Response, 3))
+	wal, err := wallet.NewWallet(cfg.HomeDir, cfg.Network, Version, logFile, buildDate)
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
+	sources, err := rateSources(cfg)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	wal.SetRateCache(wallet.NewRateCache(cfg.RateCacheTTL, sources...))
+	wal.SetBlockExplorer(blockExplorer(cfg))
+	wal.SetLogLevels(logLevels{})
+	wal.SetConfigSummary(configSummary(cfg))
+
+	if cfg.NoGUI {
+		if err := runCLI(wal, cfg.Args); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// the multiwallet is loaded before the JSON-RPC server starts, as the
+	// server reads it from its own goroutines. A load error is shown by
+	// the window.
+	loadErr := wal.InitMultiWallet()
+
+	var rpcServer *rpcserver.Server
+	if cfg.RPCPort > 0 && loadErr == nil {
+		rpcServer = rpcserver.New(wal, cfg.RPCPort, cfg.RPCUser, cfg.RPCPass)
+		if err := rpcServer.Start(); err != nil {
+			log.Errorf("Could not start JSON-RPC server: %v", err)
+			return
+		}
+	}
+
 	shutdown := make(chan int)
 	go func() {
 		<-shutdown
+		if rpcServer != nil {
+			rpcServer.Stop()
+		}
 		wal.Shutdown()
 		os.Exit(0)
 	}()
 
-	win, appWindow, err := ui.CreateWindow(wal)
+	win, appWindow, err := ui.CreateWindow(wal, loadErr)
 	if err != nil {
 		fmt.Printf("Could not initialize window: %s\ns", err)
 		return
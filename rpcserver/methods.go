@@ -0,0 +1,151 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+type handler func(s *Server, params json.RawMessage) (interface{}, error)
+
+// handlers maps the JSON-RPC methods to their handlers.
+var handlers = map[string]handler{
+	"getmultiwalletinfo": handleGetMultiWalletInfo,
+	"getalltransactions": handleGetAllTransactions,
+	"currentaddress":     handleCurrentAddress,
+	"nextaddress":        handleNextAddress,
+	"verifymessage":      handleVerifyMessage,
+	"sendtoaddress":      handleSendToAddress,
+	"syncstatus":         handleSyncStatus,
+}
+
+type transactionsParams struct {
+	Offset int32 `json:"offset"`
+	Limit  int32 `json:"limit"`
+	Filter int32 `json:"filter"`
+}
+
+type accountParams struct {
+	WalletID int   `json:"walletid"`
+	Account  int32 `json:"account"`
+}
+
+type verifyMessageParams struct {
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+type sendToAddressParams struct {
+	WalletID   int     `json:"walletid"`
+	Account    int32   `json:"account"`
+	Address    string  `json:"address"`
+	Amount     float64 `json:"amount"`
+	Passphrase string  `json:"passphrase"`
+}
+
+// SyncStatus is the result of the syncstatus method.
+type SyncStatus struct {
+	Synced          bool  `json:"synced"`
+	Syncing         bool  `json:"syncing"`
+	ConnectedPeers  int32 `json:"connectedpeers"`
+	BestBlockHeight int32 `json:"bestblockheight"`
+}
+
+// parseParams decodes the named parameters of a request into v. Methods
+// without required parameters may be called without params.
+func parseParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+
+	err := json.Unmarshal(params, v)
+	if err != nil {
+		return &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func handleGetMultiWalletInfo(s *Server, _ json.RawMessage) (interface{}, error) {
+	info, err := s.wal.MultiWalletInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	// the encrypted seed never leaves the app
+	for i := range info.Wallets {
+		info.Wallets[i].Seed = nil
+	}
+
+	return info, nil
+}
+
+func handleGetAllTransactions(s *Server, params json.RawMessage) (interface{}, error) {
+	p := transactionsParams{Filter: dcrlibwallet.TxFilterAll}
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	return s.wal.AllTransactions(p.Offset, p.Limit, p.Filter)
+}
+
+func handleCurrentAddress(s *Server, params json.RawMessage) (interface{}, error) {
+	var p accountParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	return s.wal.CurrentAddress(p.WalletID, p.Account)
+}
+
+func handleNextAddress(s *Server, params json.RawMessage) (interface{}, error) {
+	var p accountParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	return s.wal.NextAddress(p.WalletID, p.Account)
+}
+
+func handleVerifyMessage(s *Server, params json.RawMessage) (interface{}, error) {
+	var p verifyMessageParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	return s.wal.VerifyMessage(p.Address, p.Message, p.Signature)
+}
+
+func handleSendToAddress(s *Server, params json.RawMessage) (interface{}, error) {
+	var p sendToAddressParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if p.Amount <= 0 {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: "amount must be positive"}
+	}
+
+	if !s.wal.GetMultiWallet().IsAddressValid(p.Address) {
+		return nil, errors.New("invalid address")
+	}
+
+	return s.wal.SendToAddress(p.WalletID, p.Account, p.Address, dcrlibwallet.AmountAtom(p.Amount), []byte(p.Passphrase))
+}
+
+func handleSyncStatus(s *Server, _ json.RawMessage) (interface{}, error) {
+	multi := s.wal.GetMultiWallet()
+	status := SyncStatus{
+		Synced:          multi.IsSynced(),
+		Syncing:         multi.IsSyncing(),
+		ConnectedPeers:  multi.ConnectedPeers(),
+		BestBlockHeight: -1,
+	}
+
+	if best := multi.GetBestBlock(); best != nil {
+		status.BestBlockHeight = best.Height
+	}
+
+	return status, nil
+}
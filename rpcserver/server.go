@@ -0,0 +1,180 @@
+// Package rpcserver provides a localhost JSON-RPC 2.0 server exposing the
+// wallet commands to other programs on the same machine.
+package rpcserver
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	errCodeParse          = -32700
+	errCodeInvalidRequest = -32600
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+	errCodeWallet         = -32000
+)
+
+// maxRequestSize is the largest request body accepted by the server.
+const maxRequestSize = 1 << 20
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      interface{}     `json:"id"`
+}
+
+type response struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   *rpcError   `json:"error,omitempty"`
+	ID      interface{} `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *rpcError) Error() string {
+	return err.Message
+}
+
+// Server is a JSON-RPC server listening on the loopback interface. Every
+// request must be authenticated with HTTP basic auth and addressed to the
+// loopback host, so web pages cannot reach it by rebinding a domain name to
+// the loopback address.
+type Server struct {
+	wal        *wallet.Wallet
+	authSHA    [sha256.Size]byte
+	hosts      map[string]bool
+	httpServer *http.Server
+}
+
+// New returns a server for the wallet commands of wal that accepts requests
+// authenticated with user and pass.
+func New(wal *wallet.Wallet, port int, user, pass string) *Server {
+	s := &Server{
+		wal:     wal,
+		authSHA: sha256.Sum256([]byte(user + ":" + pass)),
+		hosts: map[string]bool{
+			fmt.Sprintf("127.0.0.1:%d", port): true,
+			fmt.Sprintf("localhost:%d", port): true,
+		},
+	}
+
+	s.httpServer = &http.Server{
+		Addr:         fmt.Sprintf("127.0.0.1:%d", port),
+		Handler:      http.HandlerFunc(s.handle),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: time.Minute,
+	}
+
+	return s
+}
+
+// Start listens on the server address and serves requests in the
+// background.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("JSON-RPC server listening on %s", s.httpServer.Addr)
+	go func() {
+		err := s.httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("JSON-RPC server stopped: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop closes the listener and any open connections.
+func (s *Server) Stop() error {
+	return s.httpServer.Close()
+}
+
+func (s *Server) authenticated(r *http.Request) bool {
+	user, pass, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+
+	authSHA := sha256.Sum256([]byte(user + ":" + pass))
+	return subtle.ConstantTimeCompare(authSHA[:], s.authSHA[:]) == 1
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if !s.hosts[strings.ToLower(r.Host)] {
+		http.Error(w, "403 Forbidden.", http.StatusForbidden)
+		return
+	}
+
+	if !s.authenticated(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="godcr RPC"`)
+		http.Error(w, "401 Unauthorized.", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "405 Method Not Allowed.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req request
+	resp := response{JSONRPC: "2.0"}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req)
+	if err != nil {
+		resp.Error = &rpcError{Code: errCodeParse, Message: err.Error()}
+	} else {
+		resp.ID = req.ID
+		resp.Result, resp.Error = s.call(req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		log.Errorf("Error writing JSON-RPC response: %v", err)
+	}
+}
+
+func (s *Server) call(req request) (interface{}, *rpcError) {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return nil, &rpcError{Code: errCodeInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}
+	}
+
+	handler, ok := handlers[req.Method]
+	if !ok {
+		return nil, &rpcError{Code: errCodeMethodNotFound, Message: fmt.Sprintf("unknown method %q", req.Method)}
+	}
+
+	if s.wal.GetMultiWallet() == nil {
+		return nil, &rpcError{Code: errCodeWallet, Message: "wallets are not loaded"}
+	}
+
+	log.Debugf("JSON-RPC request %s", req.Method)
+	result, err := handler(s, req.Params)
+	if err != nil {
+		var rpcErr *rpcError
+		if errors.As(err, &rpcErr) {
+			return nil, rpcErr
+		}
+		return nil, &rpcError{Code: errCodeWallet, Message: err.Error()}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,193 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const testPort = 7778
+
+func newTestServer() *Server {
+	return New(new(wallet.Wallet), testPort, "user", "pass")
+}
+
+// serve sends a request with body to the handler of s and returns the
+// recorded response.
+func serve(s *Server, method, host, body string, auth func(*http.Request)) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/", strings.NewReader(body))
+	r.Host = host
+	if auth != nil {
+		auth(r)
+	}
+	w := httptest.NewRecorder()
+	s.handle(w, r)
+	return w
+}
+
+func basicAuth(user, pass string) func(*http.Request) {
+	return func(r *http.Request) {
+		r.SetBasicAuth(user, pass)
+	}
+}
+
+func TestServerRejectsRequests(t *testing.T) {
+	s := newTestServer()
+	body := `{"jsonrpc": "2.0", "method": "syncstatus", "id": 1}`
+
+	tests := []struct {
+		name   string
+		host   string
+		auth   func(*http.Request)
+		method string
+		status int
+	}{
+		{"no auth", "127.0.0.1:7778", nil, http.MethodPost, http.StatusUnauthorized},
+		{"wrong user", "127.0.0.1:7778", basicAuth("admin", "pass"), http.MethodPost, http.StatusUnauthorized},
+		{"wrong pass", "127.0.0.1:7778", basicAuth("user", "password"), http.MethodPost, http.StatusUnauthorized},
+		{"rebound host", "evil.example.com:7778", basicAuth("user", "pass"), http.MethodPost, http.StatusForbidden},
+		{"other port", "127.0.0.1:8080", basicAuth("user", "pass"), http.MethodPost, http.StatusForbidden},
+		{"host without port", "localhost", basicAuth("user", "pass"), http.MethodPost, http.StatusForbidden},
+		{"GET", "localhost:7778", basicAuth("user", "pass"), http.MethodGet, http.StatusMethodNotAllowed},
+	}
+	for _, test := range tests {
+		w := serve(s, test.method, test.host, body, test.auth)
+		if w.Code != test.status {
+			t.Errorf("%s: got status %d, want %d", test.name, w.Code, test.status)
+		}
+	}
+
+	w := serve(s, http.MethodPost, "127.0.0.1:7778", body, nil)
+	if got := w.Header().Get("WWW-Authenticate"); got == "" {
+		t.Errorf("got no WWW-Authenticate header on an unauthenticated request")
+	}
+
+	// hosts are matched case insensitively
+	w = serve(s, http.MethodPost, "LocalHost:7778", body, basicAuth("user", "pass"))
+	if w.Code != http.StatusOK {
+		t.Errorf("got status %d for an authenticated loopback request", w.Code)
+	}
+}
+
+func TestServerDispatch(t *testing.T) {
+	s := newTestServer()
+
+	tests := []struct {
+		name string
+		body string
+		id   interface{}
+		code int
+	}{
+		{"invalid JSON", `{"jsonrpc": "2.0", "method": `, nil, errCodeParse},
+		{"request too large", `{"jsonrpc": "2.0", "method": "syncstatus", "params": "` + strings.Repeat("a", maxRequestSize) + `"}`, nil, errCodeParse},
+		{"wrong version", `{"jsonrpc": "1.0", "method": "syncstatus", "id": 1}`, 1.0, errCodeInvalidRequest},
+		{"no method", `{"jsonrpc": "2.0", "id": "a"}`, "a", errCodeInvalidRequest},
+		{"unknown method", `{"jsonrpc": "2.0", "method": "dumpprivkey", "id": 2}`, 2.0, errCodeMethodNotFound},
+		{"wallets not loaded", `{"jsonrpc": "2.0", "method": "syncstatus", "id": 3}`, 3.0, errCodeWallet},
+	}
+	for _, test := range tests {
+		w := serve(s, http.MethodPost, "127.0.0.1:7778", test.body, basicAuth("user", "pass"))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: got status %d, want %d", test.name, w.Code, http.StatusOK)
+			continue
+		}
+		if got := w.Header().Get("Content-Type"); got != "application/json" {
+			t.Errorf("%s: got content type %q", test.name, got)
+		}
+
+		var resp struct {
+			JSONRPC string      `json:"jsonrpc"`
+			Result  interface{} `json:"result"`
+			Error   *rpcError   `json:"error"`
+			ID      interface{} `json:"id"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if resp.JSONRPC != "2.0" || resp.ID != test.id {
+			t.Errorf("%s: got version %q and id %v, want 2.0 and %v", test.name, resp.JSONRPC, resp.ID, test.id)
+		}
+		if resp.Error == nil || resp.Error.Code != test.code {
+			t.Errorf("%s: got error %+v, want code %d", test.name, resp.Error, test.code)
+		}
+		if resp.Result != nil {
+			t.Errorf("%s: got result %v with an error", test.name, resp.Result)
+		}
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	p := transactionsParams{Limit: 10}
+	for _, params := range []string{"", "null"} {
+		if err := parseParams(json.RawMessage(params), &p); err != nil || p.Limit != 10 {
+			t.Errorf("params %q: got %+v, %v, want the defaults kept", params, p, err)
+		}
+	}
+
+	if err := parseParams(json.RawMessage(`{"offset": 5, "limit": 20}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Offset != 5 || p.Limit != 20 {
+		t.Errorf("got %+v, want offset 5 and limit 20", p)
+	}
+
+	err := parseParams(json.RawMessage(`{"limit": "all"}`), &p)
+	rpcErr, ok := err.(*rpcError)
+	if !ok || rpcErr.Code != errCodeInvalidParams {
+		t.Errorf("got %v, want an invalid params error", err)
+	}
+}
+
+func TestServerCallsMethods(t *testing.T) {
+	wal, err := wallet.NewWallet(t.TempDir(), "testnet3", "test", "", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wal.InitMultiWallet(); err != nil {
+		t.Fatal(err)
+	}
+	defer wal.Shutdown()
+	s := New(wal, testPort, "user", "pass")
+
+	tests := []struct {
+		name   string
+		body   string
+		result string
+		code   int
+	}{
+		{"syncstatus", `{"jsonrpc": "2.0", "method": "syncstatus", "id": 1}`,
+			`{"synced":false,"syncing":false,"connectedpeers":0,"bestblockheight":-1}`, 0},
+		{"getmultiwalletinfo", `{"jsonrpc": "2.0", "method": "getmultiwalletinfo", "id": 2}`, "", 0},
+		{"invalid params", `{"jsonrpc": "2.0", "method": "nextaddress", "params": {"walletid": "1"}, "id": 3}`, "", errCodeInvalidParams},
+		{"missing wallet", `{"jsonrpc": "2.0", "method": "nextaddress", "params": {"walletid": 1}, "id": 4}`, "", errCodeWallet},
+		{"nonpositive amount", `{"jsonrpc": "2.0", "method": "sendtoaddress", "params": {"walletid": 1, "amount": 0}, "id": 5}`, "", errCodeInvalidParams},
+	}
+	for _, test := range tests {
+		w := serve(s, http.MethodPost, "127.0.0.1:7778", test.body, basicAuth("user", "pass"))
+
+		var resp struct {
+			Result json.RawMessage `json:"result"`
+			Error  *rpcError       `json:"error"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if test.code == 0 {
+			if resp.Error != nil || resp.Result == nil {
+				t.Errorf("%s: got error %v, want a result", test.name, resp.Error)
+			}
+			if test.result != "" && string(resp.Result) != test.result {
+				t.Errorf("%s: got %s, want %s", test.name, resp.Result, test.result)
+			}
+		} else if resp.Error == nil || resp.Error.Code != test.code {
+			t.Errorf("%s: got error %+v, want code %d", test.name, resp.Error, test.code)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"embed"
 	"image"
+	_ "image/png" // the icons are decoded in init
 	"strings"
 )
 
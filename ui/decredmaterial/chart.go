@@ -0,0 +1,74 @@
+package decredmaterial
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// BarChart draws a bar for each value, scaled so the largest value fills
+// the height of the chart.
+type BarChart struct {
+	Values  []float32
+	Color   color.NRGBA
+	Height  unit.Value
+	Spacing unit.Value
+	Radius  unit.Value
+}
+
+func (t *Theme) BarChart(values []float32) BarChart {
+	return BarChart{
+		Values:  values,
+		Color:   t.Color.Primary,
+		Height:  unit.Dp(80),
+		Spacing: unit.Dp(4),
+		Radius:  unit.Dp(2),
+	}
+}
+
+func (b BarChart) Layout(gtx C) D {
+	size := image.Point{X: gtx.Constraints.Max.X, Y: gtx.Px(b.Height)}
+	if len(b.Values) == 0 {
+		return D{Size: size}
+	}
+
+	var max float32
+	for _, v := range b.Values {
+		if v > max {
+			max = v
+		}
+	}
+
+	spacing := float32(gtx.Px(b.Spacing))
+	barWidth := (float32(size.X) - spacing*float32(len(b.Values)-1)) / float32(len(b.Values))
+	if barWidth < 1 {
+		barWidth = 1
+	}
+	radius := float32(gtx.Px(b.Radius))
+
+	for i, v := range b.Values {
+		if v <= 0 || max <= 0 {
+			continue
+		}
+
+		height := float32(size.Y) * v / max
+		x := float32(i) * (barWidth + spacing)
+
+		st := op.Save(gtx.Ops)
+		op.Offset(f32.Pt(x, float32(size.Y)-height)).Add(gtx.Ops)
+		clip.RRect{
+			Rect: f32.Rectangle{Max: f32.Pt(barWidth, height)},
+			NW:   radius, NE: radius,
+		}.Add(gtx.Ops)
+		paint.ColorOp{Color: b.Color}.Add(gtx.Ops)
+		paint.PaintOp{}.Add(gtx.Ops)
+		st.Load()
+	}
+
+	return D{Size: size}
+}
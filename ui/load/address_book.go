@@ -0,0 +1,233 @@
+package load
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AddressBookConfigKey is the multiwallet user config key the address book
+// is saved under.
+const AddressBookConfigKey = "address_book"
+
+// Contact is a named address saved in the address book.
+type Contact struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// errConfigNotFound is the error text of storm.ErrNotFound, which
+// ReadUserConfigValue returns for a key that was never saved.
+const errConfigNotFound = "not found"
+
+// contactStore keeps the address book and validates addresses.
+// *dcrlibwallet.MultiWallet implements it.
+type contactStore interface {
+	ReadUserConfigValue(key string, valueOut interface{}) error
+	SaveUserConfigValue(key string, value interface{})
+	IsAddressValid(address string) bool
+}
+
+// addressBook reads and changes the contacts saved in a contactStore.
+type addressBook struct {
+	store contactStore
+}
+
+func (wl *WalletLoad) addressBook() addressBook {
+	return addressBook{store: wl.MultiWallet}
+}
+
+// Contacts returns the saved contacts sorted by name.
+func (wl *WalletLoad) Contacts() ([]Contact, error) {
+	return wl.addressBook().contacts()
+}
+
+// ContactForAddress returns the contact saved for address, if any.
+func (wl *WalletLoad) ContactForAddress(address string) (Contact, bool) {
+	contacts, _ := wl.Contacts()
+	for _, c := range contacts {
+		if c.Address == address {
+			return c, true
+		}
+	}
+	return Contact{}, false
+}
+
+// AddContact saves a new contact. Each address can only be saved once.
+func (wl *WalletLoad) AddContact(c Contact) error {
+	return wl.addressBook().update("", c)
+}
+
+// UpdateContact replaces the contact saved for address with c. A new contact
+// is added if address is empty.
+func (wl *WalletLoad) UpdateContact(address string, c Contact) error {
+	return wl.addressBook().update(address, c)
+}
+
+// DeleteContact removes the contact saved for address.
+func (wl *WalletLoad) DeleteContact(address string) error {
+	return wl.addressBook().delete(address)
+}
+
+// ExportContacts writes the address book to path as a JSON array.
+func (wl *WalletLoad) ExportContacts(path string) error {
+	return wl.addressBook().export(path)
+}
+
+// ImportContacts adds the contacts in the JSON file at path to the address
+// book. Contacts whose address is already saved are skipped. Nothing is
+// imported if the file has an invalid contact. The number of contacts added
+// is returned.
+func (wl *WalletLoad) ImportContacts(path string) (int, error) {
+	return wl.addressBook().importFile(path)
+}
+
+// contacts returns the saved contacts sorted by name. A missing address book
+// is empty, any other read error is returned so that the callers never save
+// over contacts they could not read.
+func (ab addressBook) contacts() ([]Contact, error) {
+	var contacts []Contact
+	err := ab.store.ReadUserConfigValue(AddressBookConfigKey, &contacts)
+	if err != nil && err.Error() != errConfigNotFound {
+		return nil, fmt.Errorf("reading the address book: %v", err)
+	}
+
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return strings.ToLower(contacts[i].Name) < strings.ToLower(contacts[j].Name)
+	})
+	return contacts, nil
+}
+
+func (ab addressBook) save(contacts []Contact) {
+	ab.store.SaveUserConfigValue(AddressBookConfigKey, contacts)
+}
+
+// validateContact trims the contact fields and checks that the name is set
+// and the address is valid for the current network.
+func (ab addressBook) validateContact(c *Contact) error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Address = strings.TrimSpace(c.Address)
+
+	if c.Name == "" {
+		return errors.New("contact name is required")
+	}
+
+	if !ab.store.IsAddressValid(c.Address) {
+		return fmt.Errorf("invalid address %s", c.Address)
+	}
+
+	return nil
+}
+
+func (ab addressBook) update(address string, c Contact) error {
+	err := ab.validateContact(&c)
+	if err != nil {
+		return err
+	}
+
+	contacts, err := ab.contacts()
+	if err != nil {
+		return err
+	}
+	index := -1
+	for i, existing := range contacts {
+		if existing.Address == address && address != "" {
+			index = i
+		} else if existing.Address == c.Address {
+			return fmt.Errorf("address is already saved as %s", existing.Name)
+		}
+	}
+
+	if index == -1 {
+		if address != "" {
+			return fmt.Errorf("no contact saved for address %s", address)
+		}
+		contacts = append(contacts, c)
+	} else {
+		contacts[index] = c
+	}
+
+	ab.save(contacts)
+	return nil
+}
+
+func (ab addressBook) delete(address string) error {
+	contacts, err := ab.contacts()
+	if err != nil {
+		return err
+	}
+
+	for i, c := range contacts {
+		if c.Address == address {
+			ab.save(append(contacts[:i], contacts[i+1:]...))
+			return nil
+		}
+	}
+	return nil
+}
+
+func (ab addressBook) export(path string) error {
+	contacts, err := ab.contacts()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(contacts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+func (ab addressBook) importFile(path string) (int, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var imported []Contact
+	err = json.Unmarshal(b, &imported)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address book file: %v", err)
+	}
+
+	contacts, err := ab.contacts()
+	if err != nil {
+		return 0, err
+	}
+	saved := make(map[string]bool, len(contacts))
+	for _, c := range contacts {
+		saved[c.Address] = true
+	}
+
+	added := 0
+	for i := range imported {
+		c := imported[i]
+		err = ab.validateContact(&c)
+		if err != nil {
+			return 0, fmt.Errorf("contact %d: %v", i+1, err)
+		}
+
+		if saved[c.Address] {
+			continue
+		}
+
+		saved[c.Address] = true
+		contacts = append(contacts, c)
+		added++
+	}
+
+	ab.save(contacts)
+	return added, nil
+}
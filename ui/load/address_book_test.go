@@ -0,0 +1,209 @@
+package load
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testContactStore keeps the address book in memory. Addresses starting
+// with "Ts" are valid.
+type testContactStore struct {
+	saved   []byte
+	readErr error
+	saves   int
+}
+
+func (s *testContactStore) ReadUserConfigValue(key string, valueOut interface{}) error {
+	if s.readErr != nil {
+		return s.readErr
+	}
+	if s.saved == nil {
+		return errors.New(errConfigNotFound)
+	}
+	return json.Unmarshal(s.saved, valueOut)
+}
+
+func (s *testContactStore) SaveUserConfigValue(key string, value interface{}) {
+	s.saved, _ = json.Marshal(value)
+	s.saves++
+}
+
+func (s *testContactStore) IsAddressValid(address string) bool {
+	return strings.HasPrefix(address, "Ts")
+}
+
+func contactNames(contacts []Contact) []string {
+	names := make([]string, len(contacts))
+	for i, c := range contacts {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func TestAddressBookAddEditDelete(t *testing.T) {
+	ab := addressBook{store: new(testContactStore)}
+
+	contacts, err := ab.contacts()
+	if err != nil || len(contacts) != 0 {
+		t.Fatalf("got %v, %v, want an empty address book", contacts, err)
+	}
+
+	if err := ab.update("", Contact{Name: " bob ", Address: " TsBob "}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ab.update("", Contact{Name: "Alice", Address: "TsAlice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	contacts, _ = ab.contacts()
+	want := []Contact{{Name: "Alice", Address: "TsAlice"}, {Name: "bob", Address: "TsBob"}}
+	if !reflect.DeepEqual(contacts, want) {
+		t.Fatalf("got %+v, want %+v sorted by name", contacts, want)
+	}
+
+	errTests := []struct {
+		name    string
+		address string
+		contact Contact
+	}{
+		{"no name", "", Contact{Name: " ", Address: "TsCarol"}},
+		{"invalid address", "", Contact{Name: "Carol", Address: "Dcarol"}},
+		{"address already saved", "", Contact{Name: "Bobby", Address: "TsBob"}},
+		{"edit to an address already saved", "TsAlice", Contact{Name: "Alice", Address: "TsBob"}},
+		{"edit a missing contact", "TsCarol", Contact{Name: "Carol", Address: "TsCarol"}},
+	}
+	for _, test := range errTests {
+		if err := ab.update(test.address, test.contact); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+
+	// an edit can keep the address or change it
+	if err := ab.update("TsBob", Contact{Name: "Bob", Address: "TsBob"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ab.update("TsAlice", Contact{Name: "Alice", Address: "TsAlice2"}); err != nil {
+		t.Fatal(err)
+	}
+	contacts, _ = ab.contacts()
+	want = []Contact{{Name: "Alice", Address: "TsAlice2"}, {Name: "Bob", Address: "TsBob"}}
+	if !reflect.DeepEqual(contacts, want) {
+		t.Fatalf("got %+v, want %+v", contacts, want)
+	}
+
+	if err := ab.delete("TsAlice2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ab.delete("TsMissing"); err != nil {
+		t.Fatal(err)
+	}
+	contacts, _ = ab.contacts()
+	if got := contactNames(contacts); !reflect.DeepEqual(got, []string{"Bob"}) {
+		t.Fatalf("got %v after deleting, want [Bob]", got)
+	}
+}
+
+func TestAddressBookReadError(t *testing.T) {
+	store := &testContactStore{saved: []byte(`[{"name":"Bob","address":"TsBob"}]`)}
+	store.readErr = errors.New("database closed")
+	ab := addressBook{store: store}
+
+	if _, err := ab.contacts(); err == nil {
+		t.Fatalf("expected the read error")
+	}
+
+	path := filepath.Join(t.TempDir(), "contacts.json")
+	if err := ioutil.WriteFile(path, []byte(`[{"name":"Alice","address":"TsAlice"}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// nothing is saved over contacts that could not be read
+	if err := ab.update("", Contact{Name: "Alice", Address: "TsAlice"}); err == nil {
+		t.Errorf("added a contact while the address book cannot be read")
+	}
+	if err := ab.delete("TsBob"); err == nil {
+		t.Errorf("deleted a contact while the address book cannot be read")
+	}
+	if _, err := ab.importFile(path); err == nil {
+		t.Errorf("imported contacts while the address book cannot be read")
+	}
+	if err := ab.export(filepath.Join(t.TempDir(), "export.json")); err == nil {
+		t.Errorf("exported an address book that cannot be read")
+	}
+	if store.saves != 0 {
+		t.Fatalf("saved the address book %d times", store.saves)
+	}
+}
+
+func TestAddressBookImportExport(t *testing.T) {
+	dir := t.TempDir()
+	ab := addressBook{store: new(testContactStore)}
+	if err := ab.update("", Contact{Name: "Bob", Address: "TsBob"}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "contacts.json")
+	input := `[
+		{"name": "Alice", "address": "TsAlice"},
+		{"name": "Bob again", "address": "TsBob"},
+		{"name": " Carol ", "address": "TsCarol"}
+	]`
+	if err := ioutil.WriteFile(path, []byte(input), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := ab.importFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added != 2 {
+		t.Fatalf("imported %d contacts, want 2", added)
+	}
+	contacts, _ := ab.contacts()
+	if got := contactNames(contacts); !reflect.DeepEqual(got, []string{"Alice", "Bob", "Carol"}) {
+		t.Fatalf("got %v, want the saved contact kept and the new ones added", got)
+	}
+
+	// the export imports to the same contacts
+	exported := filepath.Join(dir, "backup", "contacts.json")
+	if err := ab.export(exported); err != nil {
+		t.Fatal(err)
+	}
+	other := addressBook{store: new(testContactStore)}
+	if added, err := other.importFile(exported); err != nil || added != 3 {
+		t.Fatalf("got %d contacts imported, %v, want 3", added, err)
+	}
+	if got, _ := other.contacts(); !reflect.DeepEqual(got, contacts) {
+		t.Fatalf("got %+v, want %+v", got, contacts)
+	}
+
+	errTests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid JSON", `[{"name": "Dave"`},
+		{"invalid contact", `[{"name": "Dave", "address": "TsDave"}, {"name": "Eve", "address": "bad"}]`},
+	}
+	for _, test := range errTests {
+		if err := ioutil.WriteFile(path, []byte(test.input), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ab.importFile(path); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+	if _, err := ab.importFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("expected an error importing a missing file")
+	}
+
+	// nothing is imported from an invalid file
+	contacts, _ = ab.contacts()
+	if got := contactNames(contacts); !reflect.DeepEqual(got, []string{"Alice", "Bob", "Carol"}) {
+		t.Fatalf("got %v after the failed imports", got)
+	}
+}
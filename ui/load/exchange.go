@@ -1,38 +1,55 @@
 package load
 
 import (
-	"encoding/json"
-	"net/http"
-
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/values"
+	"golang.org/x/text/currency"
 	"golang.org/x/text/message"
 )
 
-func GetUSDExchangeValue(target interface{}) error {
-	url := "https://api.bittrex.com/v3/markets/DCR-USDT/ticker"
-	res, err := http.Get(url)
-	// TODO: include user agent in req header
-	if err != nil {
-		return err
+// legacyUSDExchangeValue is the currency conversion value saved by older
+// versions of the app that only supported USD.
+const legacyUSDExchangeValue = "USD (Bittrex)"
+
+// ExchangeCurrency returns the ISO 4217 code of the fiat currency selected
+// in the settings page or an empty string if currency conversion is off.
+func (wl *WalletLoad) ExchangeCurrency() string {
+	value := wl.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
+	if value == legacyUSDExchangeValue {
+		return "USD"
 	}
 
-	defer res.Body.Close()
+	if _, ok := values.ArrExchangeCurrencies[value]; ok {
+		return value
+	}
+
+	return ""
+}
 
-	err = json.NewDecoder(res.Body).Decode(target)
+// FormatFiat formats amount in the fiat currency code with the given number
+// of decimal places. Only the number format and the currency symbol follow
+// the printer's locale: the symbol is always written before the number, as
+// golang.org/x/text does not implement the currency patterns of locales.
+// Unknown currency codes are written after the number.
+func FormatFiat(p *message.Printer, code string, amount float64, decimals int) string {
+	unit, err := currency.ParseISO(code)
 	if err != nil {
-		return err
+		return p.Sprintf("%.*f %s", decimals, amount, code)
 	}
 
-	return nil
+	return p.Sprintf("%v%.*f", currency.NarrowSymbol(unit), decimals, amount)
 }
 
-func FormatUSDBalance(p *message.Printer, balance float64) string {
-	return p.Sprintf("$%.2f", balance)
+// FormatFiatBalance formats balance in the fiat currency code with two
+// decimal places.
+func FormatFiatBalance(p *message.Printer, code string, balance float64) string {
+	return FormatFiat(p, code, balance, 2)
 }
 
-func DCRToUSD(exchangeRate, dcr float64) float64 {
+func DCRToFiat(exchangeRate, dcr float64) float64 {
 	return dcr * exchangeRate
 }
 
-func USDToDCR(exchangeRate, usd float64) float64 {
-	return usd / exchangeRate
+func FiatToDCR(exchangeRate, fiat float64) float64 {
+	return fiat / exchangeRate
 }
@@ -0,0 +1,30 @@
+package load
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestFormatFiat(t *testing.T) {
+	tests := []struct {
+		lang     language.Tag
+		code     string
+		amount   float64
+		decimals int
+		want     string
+	}{
+		{language.English, "USD", 1234.5, 2, "$1,234.50"},
+		{language.English, "EUR", 0.00125, 4, "€0.0013"},
+		{language.German, "EUR", 1234.5, 2, "€1.234,50"},
+		{language.French, "USD", 1234.5, 2, "$1 234,50"},
+		{language.English, "XYZW", 1234.5, 2, "1,234.50 XYZW"},
+	}
+	for _, test := range tests {
+		got := FormatFiat(message.NewPrinter(test.lang), test.code, test.amount, test.decimals)
+		if got != test.want {
+			t.Errorf("%v %s: got %q, want %q", test.lang, test.code, got, test.want)
+		}
+	}
+}
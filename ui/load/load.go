@@ -19,19 +19,13 @@ import (
 	"github.com/planetdecred/godcr/ui/assets"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/notification"
+	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
 )
 
-type DCRUSDTBittrex struct {
-	LastTradeRate string
-}
-
 type Receiver struct {
-	InternalLog         chan string
-	NotificationsUpdate chan interface{}
-	KeyEvents           chan *key.Event
-	AcctMixerStatus     chan *wallet.AccountMixer
-	SyncedProposal      chan *wallet.Proposal
+	InternalLog chan string
+	KeyEvents   chan *key.Event
 }
 
 type Icons struct {
@@ -101,10 +95,7 @@ func NewLoad() (*Load, error) {
 		SelectedProposal: new(dcrlibwallet.Proposal),
 	}
 
-	r := &Receiver{
-		AcctMixerStatus: make(chan *wallet.AccountMixer),
-		SyncedProposal:  make(chan *wallet.Proposal),
-	}
+	r := &Receiver{}
 
 	icons := loadIcons()
 	th := decredmaterial.NewTheme(assets.FontCollection(), assets.DecredIcons, false)
@@ -131,6 +122,55 @@ func (l *Load) RefreshTheme() {
 	}
 }
 
+// SelectedUTXOs returns the unspent outputs selected through coin control
+// for the account. The returned map is stored in SelectedUTXO so selections
+// can be added to and removed from it directly.
+func (l *Load) SelectedUTXOs(walletID int, accountNumber int32) map[string]*wallet.UnspentOutput {
+	if l.SelectedUTXO == nil {
+		l.SelectedUTXO = make(map[int]map[int32]map[string]*wallet.UnspentOutput)
+	}
+	if l.SelectedUTXO[walletID] == nil {
+		l.SelectedUTXO[walletID] = make(map[int32]map[string]*wallet.UnspentOutput)
+	}
+	if l.SelectedUTXO[walletID][accountNumber] == nil {
+		l.SelectedUTXO[walletID][accountNumber] = make(map[string]*wallet.UnspentOutput)
+	}
+	return l.SelectedUTXO[walletID][accountNumber]
+}
+
+// PruneSelectedUTXOs removes the outputs that are not in unspent, the
+// unspent outputs of the account, from its coin control selection. It
+// returns the number of outputs removed.
+func (l *Load) PruneSelectedUTXOs(walletID int, accountNumber int32, unspent []*wallet.UnspentOutput) int {
+	keys := make(map[string]bool, len(unspent))
+	for _, utxo := range unspent {
+		keys[utxo.UTXO.OutputKey] = true
+	}
+
+	selected := l.SelectedUTXOs(walletID, accountNumber)
+	removed := 0
+	for key := range selected {
+		if !keys[key] {
+			delete(selected, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// ClearSelectedUTXOs removes the coin control selection of the account.
+func (l *Load) ClearSelectedUTXOs(walletID int, accountNumber int32) {
+	if l.SelectedUTXO[walletID] != nil {
+		delete(l.SelectedUTXO[walletID], accountNumber)
+	}
+}
+
+// RefreshPrinter updates the printer used to format fiat amounts to the
+// number format of the user's language.
+func (l *Load) RefreshPrinter() {
+	l.Printer = message.NewPrinter(language.Make(values.UserLanguages[0]))
+}
+
 func loadIcons() Icons {
 	decredIcons := assets.DecredIcons
 
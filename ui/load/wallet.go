@@ -98,14 +98,7 @@ func (wl *WalletLoad) SpendableWalletBalance(walletID int) (dcrutil.Amount, erro
 }
 
 func (wl *WalletLoad) HDPrefix() string {
-	switch wl.Wallet.Net {
-	case "testnet3": // should use a constant
-		return dcrlibwallet.TestnetHDPath
-	case "mainnet":
-		return dcrlibwallet.MainnetHDPath
-	default:
-		return ""
-	}
+	return wl.Wallet.NetworkParams().HDPath
 }
 
 func (wl *WalletLoad) WalletDirectory() string {
@@ -0,0 +1,153 @@
+package modal
+
+import (
+	"fmt"
+
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const LabelModalID = "label_modal"
+
+// LabelModal edits the label and note of a transaction, address or output.
+// Clearing both removes the label.
+type LabelModal struct {
+	*load.Load
+
+	modal    decredmaterial.Modal
+	randomID string
+	walletID int
+	label    wallet.Label
+	keyEvent chan *key.Event
+
+	title       string
+	labelEditor decredmaterial.Editor
+	noteEditor  decredmaterial.Editor
+	btnPositve  decredmaterial.Button
+	btnNegative decredmaterial.Button
+
+	saved func(wallet.Label) // called after the label is saved
+}
+
+// NewLabelModal returns a modal that edits the label of labelType and ref
+// of a wallet.
+func NewLabelModal(l *load.Load, walletID int, labelType, ref string) *LabelModal {
+	lm := &LabelModal{
+		Load:        l,
+		modal:       *l.Theme.ModalFloatTitle(),
+		randomID:    fmt.Sprintf("%s-%d", LabelModalID, generateRandomNumber()),
+		walletID:    walletID,
+		label:       l.WL.Wallet.Label(walletID, labelType, ref),
+		keyEvent:    l.Receiver.KeyEvents,
+		title:       "Edit label",
+		btnPositve:  l.Theme.Button("Save"),
+		btnNegative: l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+	lm.label.Type, lm.label.Ref = labelType, ref
+
+	lm.btnPositve.Font.Weight = text.Medium
+	lm.btnNegative.Font.Weight = text.Medium
+	lm.btnNegative.Margin = layout.Inset{Right: values.MarginPadding8}
+
+	lm.labelEditor = l.Theme.Editor(new(widget.Editor), "Label")
+	lm.labelEditor.Editor.SingleLine, lm.labelEditor.Editor.Submit = true, true
+	lm.labelEditor.Editor.SetText(lm.label.Label)
+
+	lm.noteEditor = l.Theme.Editor(new(widget.Editor), "Note")
+	lm.noteEditor.Editor.SetText(lm.label.Note)
+
+	return lm
+}
+
+func (lm *LabelModal) ModalID() string {
+	return lm.randomID
+}
+
+func (lm *LabelModal) Show() {
+	lm.ShowModal(lm)
+}
+
+func (lm *LabelModal) Dismiss() {
+	lm.DismissModal(lm)
+}
+
+func (lm *LabelModal) OnResume() {
+	lm.labelEditor.Editor.Focus()
+}
+
+func (lm *LabelModal) OnDismiss() {}
+
+func (lm *LabelModal) Title(title string) *LabelModal {
+	lm.title = title
+	return lm
+}
+
+func (lm *LabelModal) LabelSaved(saved func(wallet.Label)) *LabelModal {
+	lm.saved = saved
+	return lm
+}
+
+func (lm *LabelModal) Handle() {
+	isSubmit, isChanged := decredmaterial.HandleEditorEvents(lm.labelEditor.Editor, lm.noteEditor.Editor)
+	if isChanged {
+		lm.noteEditor.SetError("")
+	}
+
+	if lm.btnPositve.Clicked() || isSubmit {
+		label := lm.label
+		label.Label, label.Note = lm.labelEditor.Editor.Text(), lm.noteEditor.Editor.Text()
+		err := lm.WL.Wallet.SetLabel(lm.walletID, label)
+		if err != nil {
+			lm.noteEditor.SetError(err.Error())
+			return
+		}
+
+		lm.Dismiss()
+		if lm.saved != nil {
+			lm.saved(lm.WL.Wallet.Label(lm.walletID, label.Type, label.Ref))
+		}
+	}
+
+	if lm.btnNegative.Clicked() {
+		lm.Dismiss()
+	}
+
+	if lm.modal.BackdropClicked(true) {
+		lm.Dismiss()
+	}
+	decredmaterial.SwitchEditors(lm.keyEvent, lm.labelEditor.Editor, lm.noteEditor.Editor)
+}
+
+func (lm *LabelModal) Layout(gtx layout.Context) D {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := lm.Theme.H6(lm.title)
+			t.Font.Weight = text.Bold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			txt := lm.Theme.Caption(lm.label.Ref)
+			txt.Color = lm.Theme.Color.Gray
+			return txt.Layout(gtx)
+		},
+		lm.labelEditor.Layout,
+		lm.noteEditor.Layout,
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(lm.btnNegative.Layout),
+					layout.Rigid(lm.btnPositve.Layout),
+				)
+			})
+		},
+	}
+
+	return lm.modal.Layout(gtx, w, 850)
+}
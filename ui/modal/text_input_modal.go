@@ -58,6 +58,11 @@ func (tm *TextInputModal) Hint(hint string) *TextInputModal {
 	return tm
 }
 
+func (tm *TextInputModal) SetText(text string) *TextInputModal {
+	tm.textInput.Editor.SetText(text)
+	return tm
+}
+
 func (tm *TextInputModal) ShowAccountInfoTip(show bool) *TextInputModal {
 	tm.showAccountWarnInfo = show
 	return tm
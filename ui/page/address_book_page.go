@@ -0,0 +1,177 @@
+package page
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"gioui.org/layout"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+const AddressBookPageID = "AddressBook"
+
+type AddressBookPage struct {
+	*load.Load
+
+	contacts    []load.Contact
+	contactList *decredmaterial.ClickableList
+
+	addContact   *decredmaterial.Clickable
+	importButton decredmaterial.Button
+	exportButton decredmaterial.Button
+	backButton   decredmaterial.IconButton
+}
+
+func NewAddressBookPage(l *load.Load) *AddressBookPage {
+	pg := &AddressBookPage{
+		Load:         l,
+		contactList:  l.Theme.NewClickableList(layout.Vertical),
+		addContact:   l.Theme.NewClickable(false),
+		importButton: l.Theme.OutlineButton("Import"),
+		exportButton: l.Theme.OutlineButton("Export"),
+	}
+
+	pg.contactList.Radius = decredmaterial.Radius(values.MarginPadding14.V)
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+func (pg *AddressBookPage) ID() string {
+	return AddressBookPageID
+}
+
+func (pg *AddressBookPage) OnResume() {
+	contacts, err := pg.WL.Contacts()
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+	}
+	pg.contacts = contacts
+}
+
+func (pg *AddressBookPage) Layout(gtx layout.Context) layout.Dimensions {
+	body := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      "Address Book",
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.PopFragment()
+			},
+			ExtraItem: pg.addContact,
+			ExtraText: "Add contact",
+			Extra: func(gtx C) D {
+				icon := pg.Icons.ContentAdd
+				icon.Color = pg.Theme.Color.DeepBlue
+				return icon.Layout(gtx, values.MarginPadding20)
+			},
+			HandleExtra: func() {
+				newContactModal(pg.Load, nil, pg.OnResume).Show()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{}.Layout(gtx,
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.importButton.Layout)
+								}),
+								layout.Rigid(pg.exportButton.Layout),
+							)
+						})
+					}),
+					layout.Rigid(pg.contactsLayout),
+				)
+			},
+		}
+		return sp.Layout(gtx)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *AddressBookPage) contactsLayout(gtx layout.Context) layout.Dimensions {
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		if len(pg.contacts) == 0 {
+			txt := pg.Theme.Body1("No contacts yet")
+			txt.Color = pg.Theme.Color.Gray2
+			return layout.Center.Layout(gtx, func(gtx C) D {
+				return layout.UniformInset(values.MarginPadding16).Layout(gtx, txt.Layout)
+			})
+		}
+
+		return pg.contactList.Layout(gtx, len(pg.contacts), func(gtx C, i int) D {
+			contact := pg.contacts[i]
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.Theme.Body1(contact.Name).Layout),
+					layout.Rigid(func(gtx C) D {
+						txt := pg.Theme.Body2(contact.Address)
+						txt.Color = pg.Theme.Color.Gray
+						return txt.Layout(gtx)
+					}),
+				)
+			})
+		})
+	})
+}
+
+func (pg *AddressBookPage) Handle() {
+	if clicked, selectedItem := pg.contactList.ItemClicked(); clicked {
+		contact := pg.contacts[selectedItem]
+		newContactModal(pg.Load, &contact, pg.OnResume).Show()
+	}
+
+	if pg.importButton.Clicked() {
+		pg.showFileModal("Import contacts", "Import", func(path string) error {
+			added, err := pg.WL.ImportContacts(path)
+			if err != nil {
+				return err
+			}
+			pg.OnResume()
+			pg.Toast.Notify(fmt.Sprintf("%d contacts imported", added))
+			return nil
+		})
+	}
+
+	if pg.exportButton.Clicked() {
+		pg.showFileModal("Export contacts", "Export", func(path string) error {
+			err := pg.WL.ExportContacts(path)
+			if err != nil {
+				return err
+			}
+			pg.Toast.Notify("Contacts exported to " + path)
+			return nil
+		})
+	}
+}
+
+// showFileModal asks for the path of the address book file and passes it
+// to action.
+func (pg *AddressBookPage) showFileModal(title, button string, action func(path string) error) {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint("File path").
+		SetText(filepath.Join(pg.WL.Wallet.Root, "address-book.json")).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		PositiveButton(button, func(path string, tim *modal.TextInputModal) bool {
+			err := action(path)
+			if err != nil {
+				tim.SetError(err.Error())
+				tim.IsLoading = false
+				return false
+			}
+			return true
+		})
+
+	textModal.Title(title).
+		NegativeButton(values.String(values.StrCancel), func() {})
+	textModal.Show()
+}
+
+func (pg *AddressBookPage) OnClose() {}
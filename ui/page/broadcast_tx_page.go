@@ -0,0 +1,232 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const BroadcastTxPageID = "BroadcastTransaction"
+
+// BroadcastTxPage decodes a raw transaction, shows a summary of it and
+// publishes it to the network again if it is an unmined transaction of a
+// loaded wallet. Transactions signed elsewhere cannot be broadcast.
+type BroadcastTxPage struct {
+	*load.Load
+	txEditor                             decredmaterial.Editor
+	clearBtn, decodeBtn, broadcastButton decredmaterial.Button
+	backButton                           decredmaterial.IconButton
+
+	tx           *wallet.RawTransaction
+	isPublishing bool
+}
+
+func NewBroadcastTxPage(l *load.Load) *BroadcastTxPage {
+	pg := &BroadcastTxPage{
+		Load: l,
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.txEditor = l.Theme.Editor(new(widget.Editor), "Transaction hex")
+
+	pg.decodeBtn = l.Theme.Button("Decode")
+	pg.decodeBtn.Font.Weight = text.Medium
+
+	pg.clearBtn = l.Theme.OutlineButton("Clear")
+	pg.clearBtn.Font.Weight = text.Medium
+
+	pg.broadcastButton = l.Theme.Button("Republish")
+	pg.broadcastButton.Font.Weight = text.Medium
+
+	return pg
+}
+
+func (pg *BroadcastTxPage) ID() string {
+	return BroadcastTxPageID
+}
+
+func (pg *BroadcastTxPage) OnResume() {
+	pg.txEditor.Editor.Focus()
+}
+
+func (pg *BroadcastTxPage) Layout(gtx layout.Context) layout.Dimensions {
+	body := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      "Republish transaction",
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.PopFragment()
+			},
+			Body: func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+								layout.Rigid(func(gtx C) D {
+									desc := pg.Theme.Caption("Paste an unmined transaction of a loaded wallet to review it and publish it again. Transactions signed elsewhere cannot be broadcast.")
+									desc.Color = pg.Theme.Color.Gray
+									return layout.Inset{Bottom: values.MarginPadding20}.Layout(gtx, desc.Layout)
+								}),
+								layout.Rigid(pg.txEditor.Layout),
+								layout.Rigid(pg.actionButtons),
+								layout.Rigid(pg.summary),
+							)
+						})
+					})
+				})
+			},
+		}
+		return sp.Layout(gtx)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *BroadcastTxPage) actionButtons(gtx layout.Context) layout.Dimensions {
+	return layout.E.Layout(gtx, func(gtx C) D {
+		return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.clearBtn.Layout)
+				}),
+				layout.Rigid(pg.decodeBtn.Layout),
+			)
+		})
+	})
+}
+
+func (pg *BroadcastTxPage) summary(gtx layout.Context) layout.Dimensions {
+	if pg.tx == nil {
+		return layout.Dimensions{}
+	}
+
+	rows := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			m := values.MarginPadding10
+			return layout.Inset{Top: m, Bottom: m}.Layout(gtx, pg.Theme.Separator().Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.summaryRow(gtx, "Hash", pg.tx.Hash)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.summaryRow(gtx, "Size", fmt.Sprintf("%d bytes", pg.tx.Size))
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.summaryRow(gtx, "Fee", pg.tx.Fee.String())
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.summaryRow(gtx, "Fee rate", fmt.Sprintf("%s/kB", pg.tx.FeeRate))
+		}),
+	}
+
+	for i, input := range pg.tx.Inputs {
+		label, input := fmt.Sprintf("Input %d", i), input
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return pg.summaryRow(gtx, label, fmt.Sprintf("%s (%s)", input.PreviousOutpoint, input.Amount))
+		}))
+	}
+
+	for i, output := range pg.tx.Outputs {
+		label, output := fmt.Sprintf("Output %d", i), output
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return pg.summaryRow(gtx, label, fmt.Sprintf("%s (%s)", strings.Join(output.Addresses, ", "), output.Amount))
+		}))
+	}
+
+	for _, problem := range pg.tx.Problems {
+		txt := pg.Theme.Body2(problem)
+		txt.Color = pg.Theme.Color.Danger
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, txt.Layout)
+		}))
+	}
+
+	rows = append(rows, layout.Rigid(func(gtx C) D {
+		return layout.E.Layout(gtx, func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, pg.broadcastButton.Layout)
+		})
+	}))
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+func (pg *BroadcastTxPage) summaryRow(gtx layout.Context, label, value string) layout.Dimensions {
+	return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Px(values.MarginPadding100)
+				txt := pg.Theme.Body2(label)
+				txt.Color = pg.Theme.Color.Gray
+				return txt.Layout(gtx)
+			}),
+			layout.Flexed(1, pg.Theme.Body2(value).Layout),
+		)
+	})
+}
+
+func (pg *BroadcastTxPage) Handle() {
+	hasText := components.StringNotEmpty(pg.txEditor.Editor.Text())
+	pg.decodeBtn.SetEnabled(hasText)
+	pg.broadcastButton.SetEnabled(pg.tx != nil && len(pg.tx.Problems) == 0 && !pg.isPublishing)
+
+	if _, isChanged := decredmaterial.HandleEditorEvents(pg.txEditor.Editor); isChanged {
+		pg.tx = nil
+		pg.txEditor.SetError("")
+	}
+
+	if pg.decodeBtn.Clicked() && hasText {
+		pg.decodeTx()
+	}
+
+	if pg.broadcastButton.Clicked() && pg.broadcastButton.Enabled() {
+		pg.broadcastTx()
+	}
+
+	if pg.clearBtn.Clicked() {
+		pg.tx = nil
+		pg.txEditor.SetError("")
+		pg.txEditor.Editor.SetText("")
+	}
+}
+
+func (pg *BroadcastTxPage) decodeTx() {
+	tx, err := pg.WL.Wallet.DecodeRawTransaction(pg.txEditor.Editor.Text())
+	if err != nil {
+		pg.tx = nil
+		pg.txEditor.SetError(err.Error())
+		return
+	}
+
+	pg.txEditor.SetError("")
+	pg.tx = tx
+}
+
+// broadcastTx publishes the decoded transaction again. Only transactions
+// decoded without problems, which are unmined transactions of the loaded
+// wallets, can be republished.
+func (pg *BroadcastTxPage) broadcastTx() {
+	pg.isPublishing = true
+	txHex := pg.txEditor.Editor.Text()
+	go func() {
+		defer func() { pg.isPublishing = false }()
+
+		err := pg.WL.Wallet.RepublishTransaction(txHex)
+		if err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+		pg.Toast.Notify("Transaction republished")
+	}()
+}
+
+func (pg *BroadcastTxPage) OnClose() {}
@@ -95,6 +95,28 @@ func (as *AccountSelector) SelectFirstWalletValidAccount() error {
 	return errors.New("no valid account found")
 }
 
+// SelectAccount selects an account by its wallet and number if it is
+// valid.
+func (as *AccountSelector) SelectAccount(walletID int, accountNumber int32) error {
+	wal := as.multiWallet.WalletWithID(walletID)
+	if wal == nil {
+		return fmt.Errorf("wallet %d not found", walletID)
+	}
+
+	account, err := wal.GetAccount(accountNumber)
+	if err != nil {
+		return err
+	}
+
+	if !as.accountIsValid(account) {
+		return errors.New("account is not valid")
+	}
+
+	as.setupSelectedAccount(account)
+	as.callback(account)
+	return nil
+}
+
 func (as *AccountSelector) setupSelectedAccount(account *dcrlibwallet.Account) {
 	wal := as.multiWallet.WalletWithID(account.WalletID)
 
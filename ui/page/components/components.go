@@ -20,13 +20,13 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const (
-	Uint32Size       = 32 << (^uint32(0) >> 32 & 1) // 32 or 64
-	MaxInt32         = 1<<(Uint32Size-1) - 1
-	USDExchangeValue = "USD (Bittrex)"
-	WalletsPageID    = "Wallets"
+	Uint32Size    = 32 << (^uint32(0) >> 32 & 1) // 32 or 64
+	MaxInt32      = 1<<(Uint32Size-1) - 1
+	WalletsPageID = "Wallets"
 )
 
 var MaxWidth = unit.Dp(800)
@@ -292,6 +292,21 @@ func LayoutTransactionRow(gtx layout.Context, l *load.Load, row TransactionRow)
 								}),
 							)
 						}),
+						layout.Rigid(func(gtx C) D {
+							// user label, or the start of the note if there is none
+							txLabel := l.WL.Wallet.Label(row.Transaction.WalletID, wallet.LabelTx, row.Transaction.Hash)
+							text := txLabel.Label
+							if text == "" {
+								text = strings.Join(strings.Fields(txLabel.Note), " ")
+							}
+							if text == "" {
+								return D{}
+							}
+
+							label := l.Theme.Label(values.TextSize14, TruncateString(text, 30))
+							label.Color = l.Theme.Color.Gray
+							return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, label.Layout)
+						}),
 					)
 				}),
 			)
@@ -823,6 +838,26 @@ func toolTipContent(inset layout.Inset, body layout.Widget) layout.Widget {
 // createOrUpdateWalletDropDown check for len of wallets to create dropDown,
 // also update the list when create, update, delete a wallet.
 func CreateOrUpdateWalletDropDown(l *load.Load, dwn **decredmaterial.DropDown, wallets []*dcrlibwallet.Wallet) {
+	*dwn = l.Theme.DropDown(walletDropDownItems(l, wallets), 1)
+}
+
+// CreateOrUpdateAllWalletsDropDown creates a wallet dropdown whose first item
+// is "All wallets" when there is more than one wallet.
+func CreateOrUpdateAllWalletsDropDown(l *load.Load, dwn **decredmaterial.DropDown, wallets []*dcrlibwallet.Wallet) {
+	items := walletDropDownItems(l, wallets)
+	if len(wallets) > 1 {
+		walletIcon := l.Icons.WalletIcon
+		walletIcon.Scale = 1
+		all := decredmaterial.DropDownItem{
+			Text: values.String(values.StrAllWallets),
+			Icon: walletIcon,
+		}
+		items = append([]decredmaterial.DropDownItem{all}, items...)
+	}
+	*dwn = l.Theme.DropDown(items, 1)
+}
+
+func walletDropDownItems(l *load.Load, wallets []*dcrlibwallet.Wallet) []decredmaterial.DropDownItem {
 	var walletDropDownItems []decredmaterial.DropDownItem
 	walletIcon := l.Icons.WalletIcon
 	walletIcon.Scale = 1
@@ -833,7 +868,7 @@ func CreateOrUpdateWalletDropDown(l *load.Load, dwn **decredmaterial.DropDown, w
 		}
 		walletDropDownItems = append(walletDropDownItems, item)
 	}
-	*dwn = l.Theme.DropDown(walletDropDownItems, 1)
+	return walletDropDownItems
 }
 
 func CreateOrderDropDown(l *load.Load) *decredmaterial.DropDown {
@@ -0,0 +1,157 @@
+package page
+
+import (
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+const contactModalID = "contact_modal"
+
+// contactModal adds a contact to the address book or edits and deletes an
+// existing one.
+type contactModal struct {
+	*load.Load
+
+	modal   decredmaterial.Modal
+	contact *load.Contact
+	changed func()
+
+	nameEditor    decredmaterial.Editor
+	addressEditor decredmaterial.Editor
+	cancel        decredmaterial.Button
+	save          decredmaterial.Button
+	delete        decredmaterial.Button
+}
+
+// newContactModal returns a modal that edits contact, or adds a new contact
+// if it is nil. changed is called after the address book is updated.
+func newContactModal(l *load.Load, contact *load.Contact, changed func()) *contactModal {
+	cm := &contactModal{
+		Load:    l,
+		modal:   *l.Theme.ModalFloatTitle(),
+		contact: contact,
+		changed: changed,
+
+		nameEditor:    l.Theme.Editor(new(widget.Editor), "Name"),
+		addressEditor: l.Theme.Editor(new(widget.Editor), "Address"),
+		cancel:        l.Theme.OutlineButton(values.String(values.StrCancel)),
+		save:          l.Theme.Button("Save"),
+		delete:        l.Theme.OutlineButton("Delete"),
+	}
+
+	cm.delete.Color = l.Theme.Color.Danger
+	cm.nameEditor.Editor.SingleLine = true
+	cm.addressEditor.Editor.SingleLine = true
+
+	if contact != nil {
+		cm.nameEditor.Editor.SetText(contact.Name)
+		cm.addressEditor.Editor.SetText(contact.Address)
+	}
+
+	return cm
+}
+
+func (cm *contactModal) ModalID() string {
+	return contactModalID
+}
+
+func (cm *contactModal) Show() {
+	cm.ShowModal(cm)
+}
+
+func (cm *contactModal) Dismiss() {
+	cm.DismissModal(cm)
+}
+
+func (cm *contactModal) OnResume() {
+	cm.nameEditor.Editor.Focus()
+}
+
+func (cm *contactModal) OnDismiss() {}
+
+func (cm *contactModal) Handle() {
+	if _, changed := decredmaterial.HandleEditorEvents(cm.nameEditor.Editor, cm.addressEditor.Editor); changed {
+		cm.addressEditor.SetError("")
+	}
+
+	cm.save.SetEnabled(EditorsNotEmpty(cm.nameEditor.Editor, cm.addressEditor.Editor))
+
+	if cm.save.Clicked() && cm.save.Enabled() {
+		contact := load.Contact{
+			Name:    cm.nameEditor.Editor.Text(),
+			Address: cm.addressEditor.Editor.Text(),
+		}
+
+		var err error
+		if cm.contact == nil {
+			err = cm.WL.AddContact(contact)
+		} else {
+			err = cm.WL.UpdateContact(cm.contact.Address, contact)
+		}
+
+		if err != nil {
+			cm.addressEditor.SetError(err.Error())
+			return
+		}
+
+		cm.changed()
+		cm.Dismiss()
+	}
+
+	if cm.delete.Clicked() && cm.contact != nil {
+		if err := cm.WL.DeleteContact(cm.contact.Address); err != nil {
+			cm.addressEditor.SetError(err.Error())
+			return
+		}
+		cm.changed()
+		cm.Dismiss()
+	}
+
+	if cm.cancel.Clicked() || cm.modal.BackdropClicked(true) {
+		cm.Dismiss()
+	}
+}
+
+func (cm *contactModal) Layout(gtx layout.Context) D {
+	title := "Add contact"
+	if cm.contact != nil {
+		title = "Edit contact"
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := cm.Theme.H6(title)
+			t.Font.Weight = text.Bold
+			return t.Layout(gtx)
+		},
+		cm.nameEditor.Layout,
+		cm.addressEditor.Layout,
+		func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if cm.contact == nil {
+						return D{}
+					}
+					return cm.delete.Layout(gtx)
+				}),
+				layout.Flexed(1, func(gtx C) D {
+					return layout.E.Layout(gtx, func(gtx C) D {
+						return layout.Flex{}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, cm.cancel.Layout)
+							}),
+							layout.Rigid(cm.save.Layout),
+						)
+					})
+				}),
+			)
+		},
+	}
+
+	return cm.modal.Layout(gtx, w, 850)
+}
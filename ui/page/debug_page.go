@@ -41,6 +41,19 @@ func NewDebugPage(l *load.Load) *DebugPage {
 				l.ChangeFragment(NewStatPage(l))
 			},
 		},
+		{
+			text: "Export diagnostics",
+			action: func() {
+				go func() {
+					path, err := exportDiagnostics(l)
+					if err != nil {
+						l.Toast.NotifyError("Error exporting diagnostics: " + err.Error())
+						return
+					}
+					l.Toast.Notify("Diagnostics saved to " + path)
+				}()
+			},
+		},
 	}
 
 	pg := &DebugPage{
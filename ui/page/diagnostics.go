@@ -0,0 +1,135 @@
+package page
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// diagnosticsLogLines is the number of log lines in the diagnostics bundle.
+const diagnosticsLogLines = 2000
+
+type diagnosticsWallet struct {
+	ID                 int
+	Name               string
+	BestBlockHeight    int32
+	BestBlockTimestamp int64
+	IsWatchingOnly     bool
+}
+
+// diagnosticsSummary is the summary.json file of the diagnostics bundle.
+type diagnosticsSummary struct {
+	Version        string
+	BuildDate      time.Time
+	Network        string
+	Synced         bool
+	Syncing        bool
+	ConnectedPeers int32
+	Wallets        []diagnosticsWallet
+	Statistics     map[string]string
+}
+
+// exportDiagnostics writes the app version, network, sync state, sync
+// history and sessions, config and the last log lines to a zip in the app
+// directory and returns its path.
+func exportDiagnostics(l *load.Load) (string, error) {
+	wal := l.WL.Wallet
+	multi := l.WL.MultiWallet
+
+	summary := diagnosticsSummary{
+		Version:        wal.Version(),
+		BuildDate:      wal.BuildDate(),
+		Network:        wal.Net,
+		Synced:         multi.IsSynced(),
+		Syncing:        multi.IsSyncing(),
+		ConnectedPeers: multi.ConnectedPeers(),
+		Statistics:     make(map[string]string),
+	}
+	for _, w := range multi.AllWallets() {
+		summary.Wallets = append(summary.Wallets, diagnosticsWallet{
+			ID:                 w.ID,
+			Name:               w.Name,
+			BestBlockHeight:    w.GetBestBlock(),
+			BestBlockTimestamp: w.GetBestBlockTimeStamp(),
+			IsWatchingOnly:     w.IsWatchingOnlyWallet(),
+		})
+	}
+	for _, stat := range appStatistics(l) {
+		summary.Statistics[stat.title] = stat.value
+	}
+
+	logLines, err := lastLogLines(wal.LogFile(), diagnosticsLogLines)
+	if err != nil {
+		logLines = []string{fmt.Sprintf("unable to read log file: %v", err)}
+	}
+
+	dir := filepath.Join(wal.Root, "diagnostics")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("godcr-diagnostics-%s.zip", time.Now().Format("20060102-150405")))
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	err = writeDiagnostics(f, summary, wal.SyncHistory(), wal.SyncSessions(), wal.ConfigSummary(), logLines)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+
+	return path, nil
+}
+
+func writeDiagnostics(f *os.File, summary diagnosticsSummary, syncHistory []wallet.SyncEvent, syncSessions []wallet.SyncSession, config string, logLines []string) error {
+	zw := zip.NewWriter(f)
+
+	writeJSON := func(name string, v interface{}) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	writeText := func(name, text string) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write([]byte(text))
+		return err
+	}
+
+	if err := writeJSON("summary.json", summary); err != nil {
+		return err
+	}
+	if err := writeJSON("sync_history.json", syncHistory); err != nil {
+		return err
+	}
+	if err := writeJSON("sync_sessions.json", syncSessions); err != nil {
+		return err
+	}
+	if err := writeText("config.txt", config); err != nil {
+		return err
+	}
+	if err := writeText("godcr.log", strings.Join(logLines, "\n")+"\n"); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
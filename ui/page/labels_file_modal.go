@@ -0,0 +1,192 @@
+package page
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+const labelsFileModalID = "labels_file_modal"
+
+// labelsFileModal exports the labels of a wallet to a file of JSON lines,
+// or imports them from one.
+type labelsFileModal struct {
+	*load.Load
+
+	modal    decredmaterial.Modal
+	wallet   *dcrlibwallet.Wallet
+	isImport bool
+	isBusy   bool
+
+	filePath decredmaterial.Editor
+	cancel   decredmaterial.Button
+	confirm  decredmaterial.Button
+}
+
+func newLabelsFileModal(l *load.Load, wal *dcrlibwallet.Wallet, isImport bool) *labelsFileModal {
+	lm := &labelsFileModal{
+		Load:     l,
+		modal:    *l.Theme.ModalFloatTitle(),
+		wallet:   wal,
+		isImport: isImport,
+
+		filePath: l.Theme.Editor(new(widget.Editor), "File"),
+		cancel:   l.Theme.OutlineButton(values.String(values.StrCancel)),
+		confirm:  l.Theme.Button("Export"),
+	}
+
+	lm.filePath.Editor.SingleLine, lm.filePath.Editor.Submit = true, true
+	if isImport {
+		lm.confirm.Text = "Import"
+	} else {
+		fileName := fmt.Sprintf("labels-%s-%s.jsonl", wal.Name, time.Now().Format("20060102-150405"))
+		lm.filePath.Editor.SetText(filepath.Join(l.WL.Wallet.Root, "exports", fileName))
+	}
+
+	return lm
+}
+
+func (lm *labelsFileModal) ModalID() string {
+	return labelsFileModalID
+}
+
+func (lm *labelsFileModal) Show() {
+	lm.ShowModal(lm)
+}
+
+func (lm *labelsFileModal) Dismiss() {
+	lm.DismissModal(lm)
+}
+
+func (lm *labelsFileModal) OnResume() {
+	lm.filePath.Editor.Focus()
+}
+
+func (lm *labelsFileModal) OnDismiss() {}
+
+func (lm *labelsFileModal) Handle() {
+	isSubmit, isChanged := decredmaterial.HandleEditorEvents(lm.filePath.Editor)
+	if isChanged {
+		lm.filePath.SetError("")
+	}
+
+	lm.confirm.SetEnabled(!lm.isBusy && strings.TrimSpace(lm.filePath.Editor.Text()) != "")
+
+	if (lm.confirm.Clicked() || isSubmit) && lm.confirm.Enabled() {
+		lm.isBusy = true
+		path := strings.TrimSpace(lm.filePath.Editor.Text())
+		if lm.isImport {
+			go lm.importLabels(path)
+		} else {
+			go lm.exportLabels(path)
+		}
+	}
+
+	if lm.cancel.Clicked() && !lm.isBusy {
+		lm.Dismiss()
+	}
+
+	if lm.modal.BackdropClicked(!lm.isBusy) {
+		lm.Dismiss()
+	}
+}
+
+func (lm *labelsFileModal) exportLabels(path string) {
+	defer func() {
+		lm.isBusy = false
+		lm.RefreshWindow()
+	}()
+
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		lm.filePath.SetError(err.Error())
+		return
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		lm.filePath.SetError(err.Error())
+		return
+	}
+
+	count, err := lm.WL.Wallet.ExportLabels(f, lm.wallet.ID)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Errorf("Error exporting labels to %s: %v", path, err)
+		lm.filePath.SetError(err.Error())
+		return
+	}
+
+	lm.Toast.Notify(fmt.Sprintf("%d labels exported to %s", count, path))
+	lm.Dismiss()
+}
+
+func (lm *labelsFileModal) importLabels(path string) {
+	defer func() {
+		lm.isBusy = false
+		lm.RefreshWindow()
+	}()
+
+	f, err := os.Open(path)
+	if err != nil {
+		lm.filePath.SetError(err.Error())
+		return
+	}
+	defer f.Close()
+
+	count, err := lm.WL.Wallet.ImportLabels(f, lm.wallet.ID)
+	if err != nil {
+		log.Errorf("Error importing labels from %s: %v", path, err)
+		lm.filePath.SetError(err.Error())
+		return
+	}
+
+	lm.Toast.Notify(fmt.Sprintf("%d labels imported", count))
+	lm.Dismiss()
+}
+
+func (lm *labelsFileModal) Layout(gtx layout.Context) D {
+	title := "Export labels"
+	if lm.isImport {
+		title = "Import labels"
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := lm.Theme.H6(title)
+			t.Font.Weight = text.Bold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			txt := lm.Theme.Body2("Labels of transactions, addresses and outputs are saved as lines of JSON in the BIP 329 format.")
+			txt.Color = lm.Theme.Color.Gray
+			return txt.Layout(gtx)
+		},
+		lm.filePath.Layout,
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, lm.cancel.Layout)
+					}),
+					layout.Rigid(lm.confirm.Layout),
+				)
+			})
+		},
+	}
+
+	return lm.modal.Layout(gtx, w, 850)
+}
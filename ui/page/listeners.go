@@ -1,7 +1,6 @@
 package page
 
 import (
-	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strconv"
@@ -11,161 +10,31 @@ import (
 	"github.com/planetdecred/godcr/wallet"
 )
 
-// Transaction notifications
-
-func (mp *MainPage) OnTransaction(transaction string) {
-	mp.UpdateBalance()
-
-	var tx dcrlibwallet.Transaction
-	err := json.Unmarshal([]byte(transaction), &tx)
-	if err == nil {
-		update := wallet.NewTransaction{
-			Transaction: &tx,
-		}
-		mp.UpdateNotification(update)
-		mp.desktopNotifier(update)
-	}
-}
-
-func (mp *MainPage) OnBlockAttached(walletID int, blockHeight int32) {
-	mp.UpdateBalance()
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage: wallet.BlockAttached,
-	})
-}
-
-func (mp *MainPage) OnTransactionConfirmed(walletID int, hash string, blockHeight int32) {
-	mp.UpdateBalance()
-}
-
-// Account mixer
-func (mp *MainPage) OnAccountMixerStarted(walletID int) {}
-func (mp *MainPage) OnAccountMixerEnded(walletID int)   {}
-
-// Politeia notifications
-func (mp *MainPage) OnProposalsSynced() {
-	mp.UpdateNotification(wallet.Proposal{
-		ProposalStatus: wallet.Synced,
-	})
-}
-
-func (mp *MainPage) OnNewProposal(proposal *dcrlibwallet.Proposal) {
-	update := wallet.Proposal{
-		ProposalStatus: wallet.NewProposalFound,
-		Proposal:       proposal,
-	}
-	mp.UpdateNotification(update)
-	mp.desktopNotifier(update)
-}
-
-func (mp *MainPage) OnProposalVoteStarted(proposal *dcrlibwallet.Proposal) {
-	update := wallet.Proposal{
-		ProposalStatus: wallet.VoteStarted,
-		Proposal:       proposal,
-	}
-	mp.UpdateNotification(update)
-	mp.desktopNotifier(update)
-}
-func (mp *MainPage) OnProposalVoteFinished(proposal *dcrlibwallet.Proposal) {
-	update := wallet.Proposal{
-		ProposalStatus: wallet.VoteFinished,
-		Proposal:       proposal,
-	}
-	mp.UpdateNotification(update)
-	mp.desktopNotifier(update)
-}
-
-// Sync notifications
-
-func (mp *MainPage) OnSyncStarted(wasRestarted bool) {
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage: wallet.SyncStarted,
-	})
-}
-
-func (mp *MainPage) OnPeerConnectedOrDisconnected(numberOfConnectedPeers int32) {
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage:          wallet.PeersConnected,
-		ConnectedPeers: numberOfConnectedPeers,
-	})
-}
-
-func (mp *MainPage) OnCFiltersFetchProgress(cfiltersFetchProgress *dcrlibwallet.CFiltersFetchProgressReport) {
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage:          wallet.CfiltersFetchProgress,
-		ProgressReport: cfiltersFetchProgress,
-	})
-}
-
-func (mp *MainPage) OnHeadersFetchProgress(headersFetchProgress *dcrlibwallet.HeadersFetchProgressReport) {
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage: wallet.HeadersFetchProgress,
-		ProgressReport: wallet.SyncHeadersFetchProgress{
-			Progress: headersFetchProgress,
+// subscribe redraws the balance, which is read on the UI goroutine, when it
+// may have changed and shows desktop notifications for received
+// transactions and proposal updates.
+func (mp *MainPage) subscribe() {
+	mp.events = mp.WL.Wallet.Events().Subscribe(wallet.EventHandlers{
+		OnTransaction: func(update wallet.NewTransaction) {
+			mp.RefreshWindow()
+			mp.desktopNotifier(update)
 		},
-	})
-}
-
-func (mp *MainPage) OnAddressDiscoveryProgress(addressDiscoveryProgress *dcrlibwallet.AddressDiscoveryProgressReport) {
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage: wallet.AddressDiscoveryProgress,
-		ProgressReport: wallet.SyncAddressDiscoveryProgress{
-			Progress: addressDiscoveryProgress,
+		OnBlock: func(wallet.NewBlock) {
+			mp.RefreshWindow()
 		},
-	})
-}
-
-func (mp *MainPage) OnHeadersRescanProgress(headersRescanProgress *dcrlibwallet.HeadersRescanProgressReport) {
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage: wallet.HeadersRescanProgress,
-		ProgressReport: wallet.SyncHeadersRescanProgress{
-			Progress: headersRescanProgress,
+		OnSync: func(update wallet.SyncStatusUpdate) {
+			switch update.Stage {
+			case wallet.SyncCompleted, wallet.BlockConfirmed:
+				mp.RefreshWindow()
+			}
+		},
+		OnProposal: func(update wallet.Proposal) {
+			if update.ProposalStatus != wallet.Synced {
+				mp.desktopNotifier(update)
+			}
 		},
 	})
 }
-func (mp *MainPage) OnSyncCompleted() {
-	mp.UpdateBalance()
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage: wallet.SyncCompleted,
-	})
-}
-
-func (mp *MainPage) OnSyncCanceled(willRestart bool) {
-	mp.UpdateNotification(wallet.SyncStatusUpdate{
-		Stage: wallet.SyncCanceled,
-	})
-}
-func (mp *MainPage) OnSyncEndedWithError(err error)          {}
-func (mp *MainPage) Debug(debugInfo *dcrlibwallet.DebugInfo) {}
-
-// Block Rescan
-
-func (mp *MainPage) OnBlocksRescanStarted(walletID int) {
-	mp.UpdateNotification(wallet.RescanUpdate{
-		Stage:    wallet.RescanStarted,
-		WalletID: walletID,
-	})
-}
-
-func (mp *MainPage) OnBlocksRescanProgress(progress *dcrlibwallet.HeadersRescanProgressReport) {
-	mp.UpdateNotification(wallet.RescanUpdate{
-		Stage:          wallet.RescanProgress,
-		WalletID:       progress.WalletID,
-		ProgressReport: progress,
-	})
-}
-
-func (mp *MainPage) OnBlocksRescanEnded(walletID int, err error) {
-	mp.UpdateNotification(wallet.RescanUpdate{
-		Stage:    wallet.RescanEnded,
-		WalletID: walletID,
-	})
-}
-
-// UpdateNotification sends notification to the notification channel depending on which channel the page uses
-func (mp *MainPage) UpdateNotification(signal interface{}) {
-	mp.Load.Receiver.NotificationsUpdate <- signal
-}
 
 func (mp *MainPage) desktopNotifier(notifier interface{}) {
 	var notification string
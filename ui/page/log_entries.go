@@ -0,0 +1,226 @@
+package page
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/decred/slog"
+)
+
+// maxLogEntries is the number of log lines kept by the log page. Older
+// lines are dropped as new ones are written.
+const maxLogEntries = 5000
+
+// logLineRegex matches the lines written by slog, e.g.
+// "2021-09-15 17:50:38.123 [INF] WALL: message".
+var logLineRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3}) \[(\w{3})\] (\w+): (.*)$`)
+
+// logEntry is a parsed log line.
+type logEntry struct {
+	time      string
+	level     slog.Level
+	subsystem string
+	message   string
+	raw       string
+}
+
+// parseLogLine parses a line of the log file. Lines that are not in the slog
+// format, such as the continuation of a multi-line message, take the level
+// and subsystem of prev.
+func parseLogLine(line string, prev logEntry) logEntry {
+	matches := logLineRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return logEntry{
+			level:     prev.level,
+			subsystem: prev.subsystem,
+			message:   line,
+			raw:       line,
+		}
+	}
+
+	level, _ := slog.LevelFromString(matches[2])
+	return logEntry{
+		time:      matches[1],
+		level:     level,
+		subsystem: matches[3],
+		message:   matches[4],
+		raw:       line,
+	}
+}
+
+// logFilter selects the log entries shown by the log page. An empty
+// subsystem matches all subsystems and search is matched case-insensitively.
+type logFilter struct {
+	minLevel  slog.Level
+	subsystem string
+	search    string
+}
+
+func (f logFilter) matches(entry logEntry) bool {
+	if entry.level < f.minLevel {
+		return false
+	}
+	if f.subsystem != "" && entry.subsystem != f.subsystem {
+		return false
+	}
+	if f.search == "" {
+		return true
+	}
+	start, _ := indexFold(entry.raw, f.search)
+	return start >= 0
+}
+
+// indexFold returns the byte offsets in s of the first match of substr,
+// compared rune by rune under Unicode case folding, or -1, -1 if there is
+// none. The offsets always fall on rune boundaries of s, unlike offsets found
+// in strings.ToLower(s), whose runes may be encoded with a different length.
+func indexFold(s, substr string) (start, end int) {
+	if substr == "" {
+		return 0, 0
+	}
+	for start = 0; start < len(s); {
+		if end = prefixFold(s[start:], substr); end >= 0 {
+			return start, start + end
+		}
+		_, size := utf8.DecodeRuneInString(s[start:])
+		start += size
+	}
+	return -1, -1
+}
+
+// prefixFold returns the length in bytes of the prefix of s that matches
+// prefix under Unicode case folding, or -1 if s does not start with prefix.
+func prefixFold(s, prefix string) int {
+	n := 0
+	for _, want := range prefix {
+		if n >= len(s) {
+			return -1
+		}
+		got, size := utf8.DecodeRuneInString(s[n:])
+		if !equalFoldRune(got, want) {
+			return -1
+		}
+		n += size
+	}
+	return n
+}
+
+// equalFoldRune reports whether a and b are equal under simple Unicode case
+// folding.
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+	return false
+}
+
+// logBuffer is a ring buffer of the latest log entries. It is safe for
+// concurrent use.
+type logBuffer struct {
+	mu      sync.Mutex
+	entries []logEntry
+	start   int
+	last    logEntry
+
+	// version is incremented on every write so readers can tell when the
+	// entries have changed.
+	version uint64
+}
+
+func newLogBuffer(size int) *logBuffer {
+	return &logBuffer{entries: make([]logEntry, 0, size)}
+}
+
+// addLine parses line and adds it to the buffer, replacing the oldest entry
+// if the buffer is full.
+func (b *logBuffer) addLine(line string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry := parseLogLine(line, b.last)
+	b.last = entry
+	b.version++
+
+	if len(b.entries) < cap(b.entries) {
+		b.entries = append(b.entries, entry)
+		return
+	}
+	b.entries[b.start] = entry
+	b.start = (b.start + 1) % len(b.entries)
+}
+
+// filter returns the entries matching f from oldest to newest, along with
+// the version of the buffer they were read at.
+func (b *logBuffer) filter(f logFilter) ([]logEntry, uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var entries []logEntry
+	for i := range b.entries {
+		entry := b.entries[(b.start+i)%len(b.entries)]
+		if f.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, b.version
+}
+
+func (b *logBuffer) currentVersion() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.version
+}
+
+// lastLogLines returns up to n of the last lines of the log file at
+// logPath.
+func lastLogLines(logPath string, n int) ([]string, error) {
+	f, err := os.Open(logPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// assume lines of up to 256 bytes to avoid reading the whole file.
+	offset := fi.Size() - int64(n)*256
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	buffer := newLogBuffer(n)
+	scanner := bufio.NewScanner(f)
+	if offset > 0 {
+		// skip the first line because it might be truncated.
+		scanner.Scan()
+	}
+	for scanner.Scan() {
+		buffer.addLine(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	entries, _ := buffer.filter(logFilter{})
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = entry.raw
+	}
+	return lines, nil
+}
@@ -0,0 +1,184 @@
+package page
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/decred/slog"
+)
+
+func TestParseLogLine(t *testing.T) {
+	prev := logEntry{level: slog.LevelWarn, subsystem: "SYNC"}
+
+	tests := []struct {
+		name string
+		line string
+		want logEntry
+	}{{
+		name: "slog line",
+		line: "2021-09-15 17:50:38.123 [INF] WALL: Synced: 1 wallet",
+		want: logEntry{
+			time:      "2021-09-15 17:50:38.123",
+			level:     slog.LevelInfo,
+			subsystem: "WALL",
+			message:   "Synced: 1 wallet",
+		},
+	}, {
+		name: "error level",
+		line: "2021-09-15 17:50:38.123 [ERR] DLWL: failed",
+		want: logEntry{
+			time:      "2021-09-15 17:50:38.123",
+			level:     slog.LevelError,
+			subsystem: "DLWL",
+			message:   "failed",
+		},
+	}, {
+		name: "continuation line",
+		line: "  at main.go:12",
+		want: logEntry{level: slog.LevelWarn, subsystem: "SYNC", message: "  at main.go:12"},
+	}, {
+		name: "unknown level",
+		line: "2021-09-15 17:50:38.123 [XYZ] WALL: message",
+		want: logEntry{
+			time:      "2021-09-15 17:50:38.123",
+			level:     slog.LevelInfo,
+			subsystem: "WALL",
+			message:   "message",
+		},
+	}, {
+		name: "empty line",
+		line: "",
+		want: logEntry{level: slog.LevelWarn, subsystem: "SYNC"},
+	}}
+
+	for _, test := range tests {
+		test.want.raw = test.line
+		if got := parseLogLine(test.line, prev); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func testLogLine(i int) string {
+	return fmt.Sprintf("2021-09-15 17:50:38.123 [INF] WALL: line %d", i)
+}
+
+func rawLines(entries []logEntry) []string {
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = entry.raw
+	}
+	return lines
+}
+
+func TestLogBufferWraparound(t *testing.T) {
+	buffer := newLogBuffer(3)
+
+	var want []string
+	for i := 0; i < 2; i++ {
+		buffer.addLine(testLogLine(i))
+		want = append(want, testLogLine(i))
+	}
+	entries, version := buffer.filter(logFilter{})
+	if got := rawLines(entries); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if version != 2 {
+		t.Fatalf("got version %d, want 2", version)
+	}
+
+	// the oldest lines are replaced once the buffer is full, and the
+	// entries are still returned from oldest to newest
+	for i := 2; i < 8; i++ {
+		buffer.addLine(testLogLine(i))
+	}
+	want = []string{testLogLine(5), testLogLine(6), testLogLine(7)}
+	entries, version = buffer.filter(logFilter{})
+	if got := rawLines(entries); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if version != 8 || buffer.currentVersion() != 8 {
+		t.Fatalf("got version %d, want 8", version)
+	}
+
+	// a continuation line takes the level and subsystem of the line before
+	// it, even when that line was the last entry of the buffer
+	buffer.addLine("continued")
+	entries, _ = buffer.filter(logFilter{subsystem: "WALL", search: "CONTINUED"})
+	if len(entries) != 1 || entries[0].level != slog.LevelInfo {
+		t.Fatalf("got %+v, want the continuation line", entries)
+	}
+
+	entries, _ = buffer.filter(logFilter{minLevel: slog.LevelWarn})
+	if len(entries) != 0 {
+		t.Fatalf("got %d entries above the minimum level, want none", len(entries))
+	}
+}
+
+func TestIndexFold(t *testing.T) {
+	tests := []struct {
+		s, substr  string
+		start, end int
+	}{
+		{"Synced Wallet", "wallet", 7, 13},
+		{"Synced Wallet", "WALLET", 7, 13},
+		{"Synced Wallet", "peers", -1, -1},
+		{"Synced Wallet", "", 0, 0},
+		// İ is two bytes but lower cases to three, so offsets found in the
+		// lower cased text do not fall on the runes of the original
+		{"İİİİ x", "x", 9, 10},
+		{"İstanbul", "STANBUL", 2, 9},
+		{"ΌΣΟΣ", "όσος", 0, 8},
+		{"273 \u212a", "k", 4, 7}, // the Kelvin sign folds to k
+		{"straße", "SS", -1, -1},
+		{"e\u0301", "\u00e9", -1, -1}, // no normalization
+	}
+
+	for _, test := range tests {
+		start, end := indexFold(test.s, test.substr)
+		if start != test.start || end != test.end {
+			t.Errorf("indexFold(%q, %q): got %d, %d, want %d, %d", test.s, test.substr, start, end, test.start, test.end)
+			continue
+		}
+		if start >= 0 && !strings.EqualFold(test.s[start:end], test.substr) {
+			t.Errorf("indexFold(%q, %q): got %q", test.s, test.substr, test.s[start:end])
+		}
+	}
+
+	// the filter finds the same matches as the highlighting
+	entry := parseLogLine("2021-09-15 17:50:38.123 [INF] WALL: İİİİ opened", logEntry{})
+	filter := logFilter{search: "OPENED"}
+	if !filter.matches(entry) {
+		t.Fatalf("entry not matched")
+	}
+}
+
+func TestLastLogLines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, testLogLine(i))
+	}
+	path := filepath.Join(dir, "godcr.log")
+	if err := ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := lastLogLines(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, lines[90:]) {
+		t.Fatalf("got %v, want %v", got, lines[90:])
+	}
+}
@@ -2,12 +2,18 @@ package page
 
 import (
 	"fmt"
+	"image/color"
 	"os"
 	"runtime"
+	"strings"
 
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
 
+	"github.com/decred/slog"
 	"github.com/nxadm/tail"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
@@ -20,6 +26,20 @@ const (
 	LogOffset = 24000
 )
 
+// logLevels are the levels of the level filter and of the debug level
+// dropdown, from the most to the least verbose.
+var logLevels = []struct {
+	name  string
+	level slog.Level
+}{
+	{"Trace", slog.LevelTrace},
+	{"Debug", slog.LevelDebug},
+	{"Info", slog.LevelInfo},
+	{"Warn", slog.LevelWarn},
+	{"Error", slog.LevelError},
+	{"Critical", slog.LevelCritical},
+}
+
 type LogPage struct {
 	*load.Load
 	tail *tail.Tail
@@ -28,8 +48,25 @@ type LogPage struct {
 	copyIcon   *decredmaterial.Image
 	backButton decredmaterial.IconButton
 
+	levelDropDown      *decredmaterial.DropDown
+	subsystemDropDown  *decredmaterial.DropDown
+	debugLevelDropDown *decredmaterial.DropDown
+	searchEditor       decredmaterial.Editor
+	pauseButton        decredmaterial.Button
+
+	subsystems []string
+	buffer     *logBuffer
+	filter     logFilter
+	paused     bool
+
+	// entries are the filtered entries on display, read from the buffer at
+	// entriesVersion.
+	entries        []logEntry
+	entriesVersion uint64
+	filterChanged  bool
+	logErr         string
+
 	logList layout.List
-	fullLog string
 }
 
 func (pg *LogPage) ID() string {
@@ -38,26 +75,57 @@ func (pg *LogPage) ID() string {
 
 func NewLogPage(l *load.Load) *LogPage {
 	pg := &LogPage{
-		Load:    l,
-		logList: layout.List{Axis: layout.Vertical, ScrollToEnd: true},
-		copyLog: l.Theme.NewClickable(true),
+		Load:          l,
+		logList:       layout.List{Axis: layout.Vertical, ScrollToEnd: true},
+		copyLog:       l.Theme.NewClickable(true),
+		buffer:        newLogBuffer(maxLogEntries),
+		filterChanged: true,
 	}
 
 	pg.copyIcon = pg.Icons.CopyIcon
 
 	pg.backButton, _ = components.SubpageHeaderButtons(l)
 
+	levelItems := []decredmaterial.DropDownItem{{Text: "All"}}
+	var debugLevelItems []decredmaterial.DropDownItem
+	for _, level := range logLevels[1:] {
+		levelItems = append(levelItems, decredmaterial.DropDownItem{Text: level.name})
+	}
+	for _, level := range logLevels {
+		debugLevelItems = append(debugLevelItems, decredmaterial.DropDownItem{Text: level.name})
+	}
+	pg.levelDropDown = l.Theme.DropDown(levelItems, 1)
+	pg.debugLevelDropDown = l.Theme.DropDown(debugLevelItems, 1)
+
+	subsystemItems := []decredmaterial.DropDownItem{{Text: "All"}}
+	if levels := l.WL.Wallet.LogLevels(); levels != nil {
+		pg.subsystems = levels.Subsystems()
+	}
+	for _, subsystem := range pg.subsystems {
+		subsystemItems = append(subsystemItems, decredmaterial.DropDownItem{Text: subsystem})
+	}
+	pg.subsystemDropDown = l.Theme.DropDown(subsystemItems, 1)
+
+	pg.searchEditor = l.Theme.Editor(new(widget.Editor), "Search logs")
+	pg.searchEditor.Editor.SingleLine = true
+
+	pg.pauseButton = l.Theme.OutlineButton("Pause")
+	pg.pauseButton.Font.Weight = text.Medium
+
 	return pg
 }
 
 func (pg *LogPage) OnResume() {
+	pg.showDebugLevel()
 	pg.watchLogs()
 }
 
 func (pg *LogPage) copyLogEntries(gtx C) {
-	go func() {
-		clipboard.WriteOp{Text: pg.fullLog}.Add(gtx.Ops)
-	}()
+	lines := make([]string, len(pg.entries))
+	for i, entry := range pg.entries {
+		lines[i] = entry.raw
+	}
+	clipboard.WriteOp{Text: strings.Join(lines, "\n")}.Add(gtx.Ops)
 }
 
 func (pg *LogPage) watchLogs() {
@@ -66,7 +134,7 @@ func (pg *LogPage) watchLogs() {
 
 		fi, err := os.Stat(logPath)
 		if err != nil {
-			pg.fullLog = fmt.Sprintf("unable to open log file: %v", err)
+			pg.logErr = fmt.Sprintf("unable to open log file: %v", err)
 			return
 		}
 
@@ -80,7 +148,7 @@ func (pg *LogPage) watchLogs() {
 		pollLogs := runtime.GOOS == "windows"
 		t, err := tail.TailFile(logPath, tail.Config{Follow: true, Poll: pollLogs, Location: &tail.SeekInfo{Offset: offset}})
 		if err != nil {
-			pg.fullLog = fmt.Sprintf("unable to tail log file: %v", err)
+			pg.logErr = fmt.Sprintf("unable to tail log file: %v", err)
 			return
 		}
 		pg.tail = t
@@ -90,13 +158,73 @@ func (pg *LogPage) watchLogs() {
 			<-t.Lines
 		}
 		for line := range t.Lines {
-			pg.fullLog += line.Text + "\n"
-			pg.RefreshWindow()
+			pg.buffer.addLine(line.Text)
+			if !pg.paused {
+				pg.RefreshWindow()
+			}
 		}
 	}()
 }
 
+// updateEntries reads the entries matching the filter from the buffer if
+// the filter or the buffer changed. The entries on display are kept while
+// the log is paused.
+func (pg *LogPage) updateEntries() {
+	if !pg.filterChanged && (pg.paused || pg.buffer.currentVersion() == pg.entriesVersion) {
+		return
+	}
+
+	pg.entries, pg.entriesVersion = pg.buffer.filter(pg.filter)
+	pg.filterChanged = false
+}
+
+// showDebugLevel selects the debug level of the selected subsystem in the
+// debug level dropdown, or of the app subsystem if all are selected.
+func (pg *LogPage) showDebugLevel() {
+	levels := pg.WL.Wallet.LogLevels()
+	if levels == nil {
+		return
+	}
+
+	subsystem := pg.filter.subsystem
+	if subsystem == "" {
+		subsystem = "GDCR"
+	}
+
+	current, _ := slog.LevelFromString(levels.Level(subsystem))
+	for i, level := range logLevels {
+		if level.level == current {
+			pg.debugLevelDropDown.SetSelectedIndex(i)
+		}
+	}
+}
+
+// setDebugLevel sets the level selected in the debug level dropdown on the
+// selected subsystem, or on all subsystems if all are selected.
+func (pg *LogPage) setDebugLevel() {
+	levels := pg.WL.Wallet.LogLevels()
+	if levels == nil {
+		return
+	}
+
+	subsystems := pg.subsystems
+	if pg.filter.subsystem != "" {
+		subsystems = []string{pg.filter.subsystem}
+	}
+
+	level := logLevels[pg.debugLevelDropDown.SelectedIndex()].level.String()
+	for _, subsystem := range subsystems {
+		if err := levels.SetLevel(subsystem, level); err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+	}
+	pg.Toast.Notify("Debug level changed")
+}
+
 func (pg *LogPage) Layout(gtx C) D {
+	pg.updateEntries()
+
 	container := func(gtx C) D {
 		sp := components.SubPage{
 			Load:       pg.Load,
@@ -119,19 +247,27 @@ func (pg *LogPage) Layout(gtx C) D {
 				pg.Toast.Notify("Copied")
 			},
 			Body: func(gtx C) D {
-				background := pg.Theme.Color.Surface
-				card := pg.Theme.Card()
-				card.Color = background
-
-				return card.Layout(gtx, func(gtx C) D {
-					gtx.Constraints.Min.X = gtx.Constraints.Max.X
-					gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
-					return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
-						return pg.logList.Layout(gtx, 1, func(gtx C, index int) D {
-							return pg.Theme.Body1(pg.fullLog).Layout(gtx)
-						})
-					})
-				})
+				return layout.Stack{Alignment: layout.N}.Layout(gtx,
+					layout.Expanded(func(gtx C) D {
+						return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+							layout.Rigid(pg.debugLevelLabel),
+							layout.Rigid(pg.searchRow),
+							layout.Flexed(1, pg.logEntries),
+						)
+					}),
+					layout.Expanded(func(gtx C) D {
+						return pg.levelDropDown.Layout(gtx, 0, false)
+					}),
+					layout.Expanded(func(gtx C) D {
+						return pg.subsystemDropDown.Layout(gtx, pg.levelDropDown.Width, false)
+					}),
+					layout.Expanded(func(gtx C) D {
+						if pg.WL.Wallet.LogLevels() == nil {
+							return D{}
+						}
+						return pg.debugLevelDropDown.Layout(gtx, 0, true)
+					}),
+				)
 			},
 		}
 		return sp.Layout(gtx)
@@ -139,7 +275,178 @@ func (pg *LogPage) Layout(gtx C) D {
 	return components.UniformPadding(gtx, container)
 }
 
-func (pg *LogPage) Handle() {}
+// debugLevelLabel labels the debug level dropdown and reserves the space
+// of the dropdowns above the log.
+func (pg *LogPage) debugLevelLabel(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	gtx.Constraints.Min.Y = gtx.Px(values.MarginPadding60)
+	if pg.WL.Wallet.LogLevels() == nil {
+		return D{Size: gtx.Constraints.Min}
+	}
+
+	return layout.NE.Layout(gtx, func(gtx C) D {
+		inset := layout.Inset{
+			Top:   values.MarginPadding15,
+			Right: unit.Dp(float32(pg.debugLevelDropDown.Width + 10)),
+		}
+		txt := pg.Theme.Body2("Debug level")
+		txt.Color = pg.Theme.Color.Gray
+		return inset.Layout(gtx, txt.Layout)
+	})
+}
+
+func (pg *LogPage) searchRow(gtx C) D {
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, pg.searchEditor.Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.pauseButton.Layout)
+			}),
+		)
+	})
+}
+
+func (pg *LogPage) logEntries(gtx C) D {
+	card := pg.Theme.Card()
+	card.Color = pg.Theme.Color.Surface
+
+	return card.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+		return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+			if pg.logErr != "" {
+				return pg.Theme.Body1(pg.logErr).Layout(gtx)
+			}
+
+			return pg.logList.Layout(gtx, len(pg.entries), func(gtx C, index int) D {
+				return pg.logEntry(gtx, pg.entries[index])
+			})
+		})
+	})
+}
+
+func (pg *LogPage) logEntry(gtx C, entry logEntry) D {
+	return layout.Inset{Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+		children := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				if entry.time == "" {
+					return D{}
+				}
+				txt := pg.Theme.Caption(entry.time)
+				txt.Color = pg.Theme.Color.Gray
+				return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, txt.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if entry.time == "" {
+					return D{}
+				}
+				txt := pg.Theme.Caption(fmt.Sprintf("[%s] %s", entry.level, entry.subsystem))
+				txt.Color = pg.levelColor(entry.level)
+				return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, txt.Layout)
+			}),
+		}
+		children = append(children, pg.highlightedText(entry.message)...)
+		return layout.Flex{}.Layout(gtx, children...)
+	})
+}
+
+// highlightedText lays out message with the matches of the search text
+// highlighted. The last segment takes the remaining width so long messages
+// wrap.
+func (pg *LogPage) highlightedText(message string) []layout.FlexChild {
+	type segment struct {
+		text  string
+		match bool
+	}
+
+	var segments []segment
+	rest := message
+	for pg.filter.search != "" {
+		start, end := indexFold(rest, pg.filter.search)
+		if start < 0 {
+			break
+		}
+		if start > 0 {
+			segments = append(segments, segment{text: rest[:start]})
+		}
+		segments = append(segments, segment{text: rest[start:end], match: true})
+		rest = rest[end:]
+	}
+	if rest != "" || len(segments) == 0 {
+		segments = append(segments, segment{text: rest})
+	}
+
+	children := make([]layout.FlexChild, len(segments))
+	for i, s := range segments {
+		s := s
+		w := func(gtx C) D {
+			txt := pg.Theme.Caption(s.text)
+			if !s.match {
+				return txt.Layout(gtx)
+			}
+
+			txt.Color = pg.Theme.Color.Surface
+			highlight := pg.Theme.Card()
+			highlight.Color = pg.Theme.Color.Primary
+			highlight.Radius = decredmaterial.Radius(2)
+			return highlight.Layout(gtx, txt.Layout)
+		}
+
+		if i == len(segments)-1 {
+			children[i] = layout.Flexed(1, w)
+		} else {
+			children[i] = layout.Rigid(w)
+		}
+	}
+	return children
+}
+
+func (pg *LogPage) levelColor(level slog.Level) color.NRGBA {
+	switch {
+	case level >= slog.LevelError:
+		return pg.Theme.Color.Danger
+	case level == slog.LevelWarn:
+		return pg.Theme.Color.Orange
+	case level <= slog.LevelDebug:
+		return pg.Theme.Color.Gray
+	default:
+		return pg.Theme.Color.Primary
+	}
+}
+
+func (pg *LogPage) Handle() {
+	for pg.levelDropDown.Changed() {
+		// "All" takes the place of the trace level.
+		pg.filter.minLevel = logLevels[pg.levelDropDown.SelectedIndex()].level
+		pg.filterChanged = true
+	}
+
+	for pg.subsystemDropDown.Changed() {
+		pg.filter.subsystem = ""
+		if index := pg.subsystemDropDown.SelectedIndex(); index > 0 {
+			pg.filter.subsystem = pg.subsystems[index-1]
+		}
+		pg.filterChanged = true
+		pg.showDebugLevel()
+	}
+
+	for pg.debugLevelDropDown.Changed() {
+		pg.setDebugLevel()
+	}
+
+	if _, isChanged := decredmaterial.HandleEditorEvents(pg.searchEditor.Editor); isChanged {
+		pg.filter.search = pg.searchEditor.Editor.Text()
+		pg.filterChanged = true
+	}
+
+	if pg.pauseButton.Clicked() {
+		pg.paused = !pg.paused
+		pg.pauseButton.Text = "Pause"
+		if pg.paused {
+			pg.pauseButton.Text = "Resume"
+		}
+	}
+}
 
 func (pg *LogPage) OnClose() {
 	if pg.tail != nil {
@@ -1,8 +1,6 @@
 package page
 
 import (
-	"strconv"
-
 	"gioui.org/layout"
 	"gioui.org/unit"
 	"gioui.org/widget"
@@ -17,6 +15,7 @@ import (
 	"github.com/planetdecred/godcr/ui/page/send"
 	"github.com/planetdecred/godcr/ui/page/tickets"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const MainPageID = "Main"
@@ -53,19 +52,24 @@ type MainPage struct {
 	currentPage   load.Page
 	pageBackStack []load.Page
 	sendPage      *send.Page // reuse value to keep data persistent onresume.
+	events        *wallet.Subscription
 
 	// page state variables
-	dcrUsdtBittrex  load.DCRUSDTBittrex
-	usdExchangeSet  bool
-	totalBalance    dcrutil.Amount
-	totalBalanceUSD string
+	// exchangeRates receives the rates fetched in the background so they
+	// are set on the UI goroutine.
+	exchangeRates    chan wallet.ExchangeRate
+	exchangeRate     float64
+	exchangeCurrency string
+	totalBalance     dcrutil.Amount
+	totalBalanceFiat string
 }
 
 func NewMainPage(l *load.Load) *MainPage {
 
 	mp := &MainPage{
-		Load:     l,
-		autoSync: true,
+		Load:          l,
+		autoSync:      true,
+		exchangeRates: make(chan wallet.ExchangeRate, 1),
 	}
 
 	// init shared page functions
@@ -168,11 +172,7 @@ func (mp *MainPage) initNavItems() {
 
 func (mp *MainPage) OnResume() {
 	// register for notifications
-	mp.WL.MultiWallet.AddAccountMixerNotificationListener(mp, MainPageID)
-	mp.WL.MultiWallet.Politeia.AddNotificationListener(mp, MainPageID)
-	mp.WL.MultiWallet.AddTxAndBlockNotificationListener(mp, MainPageID)
-	mp.WL.MultiWallet.AddSyncProgressListener(mp, MainPageID)
-	mp.WL.MultiWallet.SetBlocksRescanProgressListener(mp)
+	mp.subscribe()
 
 	mp.setLanguageSetting()
 	mp.UpdateBalance()
@@ -189,28 +189,52 @@ func (mp *MainPage) OnResume() {
 		go mp.WL.MultiWallet.Politeia.Sync()
 	}
 
-	load.GetUSDExchangeValue(&mp.dcrUsdtBittrex)
+	go mp.fetchExchangeRate()
+}
+
+func (mp *MainPage) fetchExchangeRate() {
+	currency := mp.WL.ExchangeCurrency()
+	if currency == "" {
+		return
+	}
+
+	rate, err := mp.WL.Wallet.ExchangeRate(currency)
+	if err != nil {
+		log.Error("Error fetching exchange rate:", err)
+		return
+	}
+
+	// drop a rate that was not read yet, the new one replaces it
+	select {
+	case <-mp.exchangeRates:
+	default:
+	}
+	mp.exchangeRates <- rate
+	mp.RefreshWindow()
 }
 
 func (mp *MainPage) setLanguageSetting() {
 	langPre := mp.WL.Wallet.ReadStringConfigValueForKey(languagePreferenceKey)
 	values.SetUserLanguage(langPre)
+	mp.RefreshPrinter()
 }
 
 func (mp *MainPage) UpdateBalance() {
-	currencyExchangeValue := mp.WL.Wallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
-	mp.usdExchangeSet = currencyExchangeValue == components.USDExchangeValue
+	currency := mp.WL.ExchangeCurrency()
+	if currency != mp.exchangeCurrency {
+		// the currency was changed in the settings, the current rate is invalid.
+		mp.exchangeCurrency = currency
+		mp.exchangeRate = 0
+		go mp.fetchExchangeRate()
+	}
 
 	totalBalance, err := mp.CalculateTotalWalletsBalance()
 	if err == nil {
 		mp.totalBalance = totalBalance
 
-		if mp.usdExchangeSet && mp.dcrUsdtBittrex.LastTradeRate != "" {
-			usdExchangeRate, err := strconv.ParseFloat(mp.dcrUsdtBittrex.LastTradeRate, 64)
-			if err == nil {
-				balanceInUSD := totalBalance.ToCoin() * usdExchangeRate
-				mp.totalBalanceUSD = load.FormatUSDBalance(mp.Printer, balanceInUSD)
-			}
+		if mp.exchangeCurrency != "" && mp.exchangeRate > 0 {
+			balanceInFiat := load.DCRToFiat(mp.exchangeRate, totalBalance.ToCoin())
+			mp.totalBalanceFiat = load.FormatFiatBalance(mp.Printer, mp.exchangeCurrency, balanceInFiat)
 		}
 
 	}
@@ -273,6 +297,14 @@ func (mp *MainPage) UnlockWalletForSyncing(wal *dcrlibwallet.Wallet) {
 }
 
 func (mp *MainPage) Handle() {
+	select {
+	case rate := <-mp.exchangeRates:
+		if rate.Currency == mp.exchangeCurrency {
+			mp.exchangeRate = rate.Rate
+		}
+	default:
+	}
+
 	mp.drawerNav.CurrentPage = mp.currentPageID()
 	mp.appBarNav.CurrentPage = mp.currentPageID()
 
@@ -335,10 +367,7 @@ func (mp *MainPage) OnClose() {
 		mp.currentPage.OnClose()
 	}
 
-	mp.WL.MultiWallet.RemoveAccountMixerNotificationListener(MainPageID)
-	mp.WL.MultiWallet.Politeia.RemoveNotificationListener(MainPageID)
-	mp.WL.MultiWallet.RemoveTxAndBlockNotificationListener(MainPageID)
-	mp.WL.MultiWallet.RemoveSyncProgressListener(MainPageID)
+	mp.events.Unsubscribe()
 }
 
 func (mp *MainPage) currentPageID() string {
@@ -441,11 +470,11 @@ func (mp *MainPage) Layout(gtx layout.Context) layout.Dimensions {
 	)
 }
 
-func (mp *MainPage) LayoutUSDBalance(gtx layout.Context) layout.Dimensions {
+func (mp *MainPage) LayoutFiatBalance(gtx layout.Context) layout.Dimensions {
 	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
 			mp.UpdateBalance()
-			if mp.usdExchangeSet && mp.dcrUsdtBittrex.LastTradeRate != "" {
+			if mp.exchangeCurrency != "" && mp.exchangeRate > 0 {
 				inset := layout.Inset{
 					Top:  values.MarginPadding3,
 					Left: values.MarginPadding8,
@@ -460,7 +489,7 @@ func (mp *MainPage) LayoutUSDBalance(gtx layout.Context) layout.Dimensions {
 					}
 					return border.Layout(gtx, func(gtx C) D {
 						return padding.Layout(gtx, func(gtx C) D {
-							return mp.Theme.Body2(mp.totalBalanceUSD).Layout(gtx)
+							return mp.Theme.Body2(mp.totalBalanceFiat).Layout(gtx)
 						})
 					})
 				})
@@ -510,7 +539,7 @@ func (mp *MainPage) LayoutTopBar(gtx layout.Context) layout.Dimensions {
 										})
 									}),
 									layout.Rigid(func(gtx C) D {
-										return mp.LayoutUSDBalance(gtx)
+										return mp.LayoutFiatBalance(gtx)
 									}),
 								)
 							})
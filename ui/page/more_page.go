@@ -42,6 +42,14 @@ func NewMorePage(l *load.Load) *MorePage {
 				l.ChangeFragment(NewSecurityToolsPage(l))
 			},
 		},
+		{
+			clickable: l.Theme.NewClickable(true),
+			image:     l.Icons.AccountIcon,
+			page:      AddressBookPageID,
+			action: func() {
+				l.ChangeFragment(NewAddressBookPage(l))
+			},
+		},
 		{
 			clickable: l.Theme.NewClickable(true),
 			image:     l.Icons.HelpIcon,
@@ -127,8 +135,11 @@ func (pg *MorePage) layoutMoreItems(gtx layout.Context) layout.Dimensions {
 					Top:  values.MarginPadding2,
 				}.Layout(gtx, func(gtx C) D {
 					page := pg.morePageListItems[i].page
-					if page == SecurityToolsPageID {
+					switch page {
+					case SecurityToolsPageID:
 						page = "Security Tools"
+					case AddressBookPageID:
+						page = "Address Book"
 					}
 					return pg.Theme.Body1(page).Layout(gtx)
 				})
@@ -1,7 +1,6 @@
 package page
 
 import (
-	"context"
 	"fmt"
 	"image/color"
 	"time"
@@ -30,8 +29,7 @@ type walletSyncDetails struct {
 
 type OverviewPage struct {
 	*load.Load
-	ctx              context.Context // page context
-	ctxCancel        context.CancelFunc
+	events           *wallet.Subscription
 	listContainer    *layout.List
 	walletSyncList   *layout.List
 	transactionsList *decredmaterial.ClickableList
@@ -116,8 +114,6 @@ func (pg *OverviewPage) ID() string {
 }
 
 func (pg *OverviewPage) OnResume() {
-	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
-
 	pg.walletSyncing = pg.WL.MultiWallet.IsSyncing()
 	pg.walletSynced = pg.WL.MultiWallet.IsSynced()
 	pg.isConnnected = pg.WL.MultiWallet.IsConnectedToDecredNetwork()
@@ -125,7 +121,7 @@ func (pg *OverviewPage) OnResume() {
 	pg.bestBlock = pg.WL.MultiWallet.GetBestBlock()
 
 	pg.loadTransactions()
-	pg.listenForSyncNotifications()
+	pg.subscribe()
 }
 
 func (pg *OverviewPage) loadTransactions() {
@@ -675,65 +671,59 @@ func (pg *OverviewPage) Handle() {
 	}
 }
 
-func (pg *OverviewPage) listenForSyncNotifications() {
-	go func() {
-		for {
-			var notification interface{}
-
-			select {
-			case notification = <-pg.Receiver.NotificationsUpdate:
-			case <-pg.ctx.Done():
-				return
-			}
-
-			switch n := notification.(type) {
-			case wallet.NewTransaction:
-				pg.loadTransactions()
-			case wallet.RescanUpdate:
-				pg.rescanningBlocks = n.Stage != wallet.RescanEnded
-				pg.rescanUpdate = &n
-			case wallet.SyncStatusUpdate:
-				switch t := n.ProgressReport.(type) {
-				case wallet.SyncHeadersFetchProgress:
-					pg.headerFetchProgress = t.Progress.HeadersFetchProgress
-					pg.headersToFetchOrScan = t.Progress.TotalHeadersToFetch
-					pg.syncProgress = int(t.Progress.TotalSyncProgress)
-					pg.remainingSyncTime = components.TimeFormat(int(t.Progress.TotalTimeRemainingSeconds), true)
-					pg.syncStep = wallet.FetchHeadersSteps
-				case wallet.SyncAddressDiscoveryProgress:
-					pg.syncProgress = int(t.Progress.TotalSyncProgress)
-					pg.remainingSyncTime = components.TimeFormat(int(t.Progress.TotalTimeRemainingSeconds), true)
-					pg.syncStep = wallet.AddressDiscoveryStep
-				case wallet.SyncHeadersRescanProgress:
-					pg.headersToFetchOrScan = t.Progress.TotalHeadersToScan
-					pg.syncProgress = int(t.Progress.TotalSyncProgress)
-					pg.remainingSyncTime = components.TimeFormat(int(t.Progress.TotalTimeRemainingSeconds), true)
-					pg.syncStep = wallet.RescanHeadersStep
-				}
+// subscribe refreshes the page on new transactions and blocks and keeps the
+// sync and rescan progress up to date.
+func (pg *OverviewPage) subscribe() {
+	pg.events = pg.WL.Wallet.Events().Subscribe(wallet.EventHandlers{
+		OnTransaction: func(wallet.NewTransaction) {
+			pg.loadTransactions()
+			pg.RefreshWindow()
+		},
+		OnBlock: func(wallet.NewBlock) {
+			pg.bestBlock = pg.WL.MultiWallet.GetBestBlock()
+			pg.RefreshWindow()
+		},
+		OnRescan: func(update wallet.RescanUpdate) {
+			pg.rescanningBlocks = update.Stage != wallet.RescanEnded
+			pg.rescanUpdate = &update
+			pg.RefreshWindow()
+		},
+		OnSync: pg.syncUpdated,
+	})
+}
 
-				switch n.Stage {
-				case wallet.PeersConnected:
-					pg.connectedPeers = n.ConnectedPeers
-				case wallet.SyncStarted:
-					fallthrough
-				case wallet.SyncCanceled:
-					fallthrough
-				case wallet.SyncCompleted:
-					pg.loadTransactions()
-					pg.walletSyncing = pg.WL.MultiWallet.IsSyncing()
-					pg.walletSynced = pg.WL.MultiWallet.IsSynced()
-					pg.isConnnected = pg.WL.MultiWallet.IsConnectedToDecredNetwork()
-				case wallet.BlockAttached:
-					pg.bestBlock = pg.WL.MultiWallet.GetBestBlock()
-				}
-			}
+func (pg *OverviewPage) syncUpdated(update wallet.SyncStatusUpdate) {
+	switch t := update.ProgressReport.(type) {
+	case wallet.SyncHeadersFetchProgress:
+		pg.headerFetchProgress = t.Progress.HeadersFetchProgress
+		pg.headersToFetchOrScan = t.Progress.TotalHeadersToFetch
+		pg.syncProgress = int(t.Progress.TotalSyncProgress)
+		pg.remainingSyncTime = components.TimeFormat(int(t.Progress.TotalTimeRemainingSeconds), true)
+		pg.syncStep = wallet.FetchHeadersSteps
+	case wallet.SyncAddressDiscoveryProgress:
+		pg.syncProgress = int(t.Progress.TotalSyncProgress)
+		pg.remainingSyncTime = components.TimeFormat(int(t.Progress.TotalTimeRemainingSeconds), true)
+		pg.syncStep = wallet.AddressDiscoveryStep
+	case wallet.SyncHeadersRescanProgress:
+		pg.headersToFetchOrScan = t.Progress.TotalHeadersToScan
+		pg.syncProgress = int(t.Progress.TotalSyncProgress)
+		pg.remainingSyncTime = components.TimeFormat(int(t.Progress.TotalTimeRemainingSeconds), true)
+		pg.syncStep = wallet.RescanHeadersStep
+	}
 
-			pg.RefreshWindow()
+	switch update.Stage {
+	case wallet.PeersConnected:
+		pg.connectedPeers = update.ConnectedPeers
+	case wallet.SyncStarted, wallet.SyncCanceled, wallet.SyncCompleted:
+		pg.loadTransactions()
+		pg.walletSyncing = pg.WL.MultiWallet.IsSyncing()
+		pg.walletSynced = pg.WL.MultiWallet.IsSynced()
+		pg.isConnnected = pg.WL.MultiWallet.IsConnectedToDecredNetwork()
+	}
 
-		}
-	}()
+	pg.RefreshWindow()
 }
 
 func (pg *OverviewPage) OnClose() {
-	pg.ctxCancel()
+	pg.events.Unsubscribe()
 }
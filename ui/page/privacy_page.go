@@ -298,11 +298,11 @@ func (pg *PrivacyPage) mixerSettingsLayout(gtx layout.Context) layout.Dimensions
 }
 
 func (pg *PrivacyPage) shufflePortForCurrentNet() string {
-	if pg.WL.Wallet.Net == "testnet3" {
-		return dcrlibwallet.TestnetShufflePort
+	if port := pg.WL.Wallet.NetworkParams().ShufflePort; port != "" {
+		return port
 	}
 
-	return dcrlibwallet.MainnetShufflePort
+	return "-"
 }
 
 func (pg *PrivacyPage) dangerZoneLayout(gtx layout.Context) layout.Dimensions {
@@ -1,7 +1,6 @@
 package proposal
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -30,8 +29,7 @@ type proposalItemWidgets struct {
 
 type proposalDetails struct {
 	*load.Load
-	ctx       context.Context // page context
-	ctxCancel context.CancelFunc
+	events *wallet.Subscription
 
 	loadingDescription bool
 	proposal           *dcrlibwallet.Proposal
@@ -88,8 +86,7 @@ func (pg *proposalDetails) ID() string {
 }
 
 func (pg *proposalDetails) OnResume() {
-	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
-	pg.listenForSyncNotifications()
+	pg.subscribe()
 }
 
 func (pg *proposalDetails) Handle() {
@@ -106,41 +103,31 @@ func (pg *proposalDetails) Handle() {
 	}
 
 	for pg.viewInPoliteiaBtn.Clicked() {
-		host := "https://proposals.decred.org/record/"
-		if pg.WL.MultiWallet.NetType() == dcrlibwallet.Testnet3 {
-			host = "https://test-proposals.decred.org/record/"
+		if proposalURL := pg.WL.Wallet.ProposalURL(pg.proposal.Token); proposalURL != "" {
+			components.GoToURL(proposalURL)
 		}
-
-		components.GoToURL(host + pg.proposal.Token)
 	}
 }
 
-func (pg *proposalDetails) listenForSyncNotifications() {
-	go func() {
-		for {
-			var notification interface{}
-
-			select {
-			case notification = <-pg.Receiver.NotificationsUpdate:
-			case <-pg.ctx.Done():
+// subscribe reloads the proposal when Politeia has synced.
+func (pg *proposalDetails) subscribe() {
+	pg.events = pg.WL.Wallet.Events().Subscribe(wallet.EventHandlers{
+		OnProposal: func(update wallet.Proposal) {
+			if update.ProposalStatus != wallet.Synced {
 				return
 			}
 
-			switch n := notification.(type) {
-			case wallet.Proposal:
-				if n.ProposalStatus == wallet.Synced {
-					proposal, err := pg.WL.MultiWallet.Politeia.GetProposalRaw(pg.proposal.Token)
-					if err == nil {
-						pg.proposal = proposal
-						pg.RefreshWindow()
-					}
-				}
+			proposal, err := pg.WL.MultiWallet.Politeia.GetProposalRaw(pg.proposal.Token)
+			if err == nil {
+				pg.proposal = proposal
+				pg.RefreshWindow()
 			}
-		}
-	}()
+		},
+	})
 }
+
 func (pg *proposalDetails) OnClose() {
-	pg.ctxCancel()
+	pg.events.Unsubscribe()
 }
 
 // - Layout
@@ -1,7 +1,6 @@
 package proposal
 
 import (
-	"context"
 	"fmt"
 	"image"
 	"image/color"
@@ -44,8 +43,7 @@ type proposalItem struct {
 type ProposalsPage struct {
 	*load.Load
 
-	ctx        context.Context // page context
-	ctxCancel  context.CancelFunc
+	events     *wallet.Subscription
 	proposalMu sync.Mutex
 
 	multiWallet *dcrlibwallet.MultiWallet
@@ -101,9 +99,7 @@ func (pg *ProposalsPage) ID() string {
 }
 
 func (pg *ProposalsPage) OnResume() {
-	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
-
-	pg.listenForSyncNotifications()
+	pg.subscribe()
 
 	pg.proposalMu.Lock()
 	selectedCategory := pg.selectedCategoryIndex
@@ -192,38 +188,30 @@ func (pg *ProposalsPage) Handle() {
 	}
 }
 
-func (pg *ProposalsPage) listenForSyncNotifications() {
-	go func() {
-		for {
-			var notification interface{}
-
-			select {
-			case notification = <-pg.Receiver.NotificationsUpdate:
-			case <-pg.ctx.Done():
+// subscribe reloads the proposals when Politeia has synced.
+func (pg *ProposalsPage) subscribe() {
+	pg.events = pg.WL.Wallet.Events().Subscribe(wallet.EventHandlers{
+		OnProposal: func(update wallet.Proposal) {
+			if update.ProposalStatus != wallet.Synced {
 				return
 			}
 
-			switch n := notification.(type) {
-			case wallet.Proposal:
-				if n.ProposalStatus == wallet.Synced {
-					pg.isSyncing = false
-					pg.showSyncedCompleted = true
-
-					pg.proposalMu.Lock()
-					selectedCategory := pg.selectedCategoryIndex
-					pg.proposalMu.Unlock()
-					if selectedCategory != -1 {
-						pg.countProposals()
-						pg.loadProposals(selectedCategory)
-					}
-				}
+			pg.isSyncing = false
+			pg.showSyncedCompleted = true
+
+			pg.proposalMu.Lock()
+			selectedCategory := pg.selectedCategoryIndex
+			pg.proposalMu.Unlock()
+			if selectedCategory != -1 {
+				pg.countProposals()
+				pg.loadProposals(selectedCategory)
 			}
-		}
-	}()
+		},
+	})
 }
 
 func (pg *ProposalsPage) OnClose() {
-	pg.ctxCancel()
+	pg.events.Unsubscribe()
 }
 
 // - Layout
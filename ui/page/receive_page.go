@@ -2,14 +2,18 @@ package page
 
 import (
 	"bytes"
+	"fmt"
 	"image"
 	"image/color"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/planetdecred/godcr/ui/assets"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/wallet"
 
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
@@ -17,6 +21,7 @@ import (
 	"gioui.org/unit"
 	"gioui.org/widget"
 
+	"github.com/decred/dcrd/dcrutil/v3"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
@@ -39,8 +44,18 @@ type ReceivePage struct {
 	receiveAddress    decredmaterial.Label
 	gtx               *layout.Context
 
+	amountEditor  decredmaterial.Editor
+	labelEditor   decredmaterial.Editor
+	messageEditor decredmaterial.Editor
+
 	selector *components.AccountSelector
 
+	// addressHistory is the current address of the selected account, the
+	// addresses generated before it on this page and its labeled addresses.
+	addressHistory    []string
+	generated         []string
+	historyClickables []*decredmaterial.Clickable
+
 	backdrop   *widget.Clickable
 	backButton decredmaterial.IconButton
 	infoButton decredmaterial.IconButton
@@ -60,8 +75,15 @@ func NewReceivePage(l *load.Load) *ReceivePage {
 		receiveAddress: l.Theme.Label(values.TextSize20, ""),
 		card:           l.Theme.Card(),
 		backdrop:       new(widget.Clickable),
+		amountEditor:   l.Theme.Editor(new(widget.Editor), "Amount (DCR)"),
+		labelEditor:    l.Theme.Editor(new(widget.Editor), "Label"),
+		messageEditor:  l.Theme.Editor(new(widget.Editor), "Message"),
 	}
 
+	pg.amountEditor.Editor.SingleLine = true
+	pg.labelEditor.Editor.SingleLine = true
+	pg.messageEditor.Editor.SingleLine = true
+
 	pg.info.Inset, pg.info.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
 	pg.copy.Background = color.NRGBA{}
 	pg.copy.HighlightColor = pg.Theme.Color.SurfaceHighlight
@@ -102,6 +124,7 @@ func NewReceivePage(l *load.Load) *ReceivePage {
 			}
 
 			pg.generateQRForAddress()
+			pg.loadAddressHistory()
 		}).
 		AccountValidator(func(account *dcrlibwallet.Account) bool {
 
@@ -125,9 +148,86 @@ func (pg *ReceivePage) OnResume() {
 	pg.selector.SelectFirstWalletValidAccount()
 }
 
+// paymentRequest returns the decred: URI for the current address and the
+// optional amount, label and message. The bare address is returned if none
+// of them is set.
+func (pg *ReceivePage) paymentRequest() string {
+	uri := wallet.PaymentURI{
+		Address: pg.currentAddress,
+		Label:   strings.TrimSpace(pg.labelEditor.Editor.Text()),
+		Message: strings.TrimSpace(pg.messageEditor.Editor.Text()),
+	}
+
+	if amount, err := pg.requestedAmount(); err == nil {
+		uri.Amount = amount
+	}
+
+	if uri.Amount == 0 && uri.Label == "" && uri.Message == "" {
+		return pg.currentAddress
+	}
+
+	return uri.String()
+}
+
+// requestedAmount parses the optional amount requested by the user. An
+// empty amount editor is a zero amount.
+func (pg *ReceivePage) requestedAmount() (dcrutil.Amount, error) {
+	text := strings.TrimSpace(pg.amountEditor.Editor.Text())
+	if text == "" {
+		return 0, nil
+	}
+
+	amount, err := strconv.ParseFloat(text, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid amount")
+	}
+
+	return dcrutil.NewAmount(amount)
+}
+
+// loadAddressHistory lists the addresses of the selected account shown in
+// the address history, newest first.
+func (pg *ReceivePage) loadAddressHistory() {
+	pg.addressHistory = nil
+	account := pg.selector.SelectedAccount()
+	if account == nil {
+		return
+	}
+	wal := pg.multiWallet.WalletWithID(account.WalletID)
+	if wal == nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	addAddress := func(address string) {
+		if address == "" || seen[address] {
+			return
+		}
+		seen[address] = true
+
+		info, err := wal.AddressInfo(address)
+		if err != nil || !info.IsMine || int32(info.AccountNumber) != account.Number {
+			return
+		}
+		pg.addressHistory = append(pg.addressHistory, address)
+	}
+
+	addAddress(pg.currentAddress)
+	for i := len(pg.generated) - 1; i >= 0; i-- {
+		addAddress(pg.generated[i])
+	}
+	for _, label := range pg.WL.Wallet.Labels(account.WalletID, wallet.LabelAddress) {
+		addAddress(label.Ref)
+	}
+
+	for len(pg.historyClickables) < len(pg.addressHistory) {
+		pg.historyClickables = append(pg.historyClickables, pg.Theme.NewClickable(true))
+	}
+}
+
 func (pg *ReceivePage) generateQRForAddress() {
 	opt := qrcode.WithLogoImage(assets.DecredIcons["qrcodeSymbol"])
-	qrCode, err := qrcode.New(pg.currentAddress, opt)
+	qrCode, err := qrcode.New(pg.paymentRequest(), opt)
 	if err != nil {
 		log.Error("Error generating address qrCode: " + err.Error())
 		return
@@ -164,6 +264,12 @@ func (pg *ReceivePage) Layout(gtx layout.Context) layout.Dimensions {
 		func(gtx C) D {
 			return pg.Theme.Separator().Layout(gtx)
 		},
+		func(gtx C) D {
+			return pg.pageSections(gtx, pg.paymentRequestLayout)
+		},
+		func(gtx C) D {
+			return pg.Theme.Separator().Layout(gtx)
+		},
 		func(gtx C) D {
 			return pg.pageSections(gtx, func(gtx C) D {
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -195,6 +301,12 @@ func (pg *ReceivePage) Layout(gtx layout.Context) layout.Dimensions {
 				)
 			})
 		},
+		func(gtx C) D {
+			return pg.Theme.Separator().Layout(gtx)
+		},
+		func(gtx C) D {
+			return pg.pageSections(gtx, pg.addressHistoryLayout)
+		},
 	}
 
 	dims := components.UniformPadding(gtx, func(gtx C) D {
@@ -280,6 +392,25 @@ func (pg *ReceivePage) titleLayout(gtx layout.Context) layout.Dimensions {
 	)
 }
 
+func (pg *ReceivePage) paymentRequestLayout(gtx layout.Context) layout.Dimensions {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Body2("Payment request (optional)")
+			txt.Color = pg.Theme.Color.Gray
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.amountEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.labelEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.messageEditor.Layout)
+		}),
+	)
+}
+
 func (pg *ReceivePage) addressLayout(gtx layout.Context) layout.Dimensions {
 	card := decredmaterial.Card{
 		Color: pg.Theme.Color.LightGray,
@@ -312,6 +443,46 @@ func (pg *ReceivePage) addressLayout(gtx layout.Context) layout.Dimensions {
 	})
 }
 
+// addressHistoryLayout lists the addresses of the address history with their
+// labels. Clicking an address edits its label.
+func (pg *ReceivePage) addressHistoryLayout(gtx layout.Context) layout.Dimensions {
+	account := pg.selector.SelectedAccount()
+	rows := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Body2("Address history")
+			txt.Color = pg.Theme.Color.Gray
+			return txt.Layout(gtx)
+		}),
+	}
+
+	for i, address := range pg.addressHistory {
+		address, clickable := address, pg.historyClickables[i]
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			addressLabel := pg.WL.Wallet.Label(account.WalletID, wallet.LabelAddress, address)
+			return clickable.Layout(gtx, func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					return components.EndToEndRow(gtx, func(gtx C) D {
+						txt := pg.Theme.Body2(address)
+						if address == pg.currentAddress {
+							txt.Color = pg.Theme.Color.DeepBlue
+						}
+						return txt.Layout(gtx)
+					}, func(gtx C) D {
+						if addressLabel.Label == "" {
+							txt := pg.Theme.Body2("Add label")
+							txt.Color = pg.Theme.Color.Gray
+							return txt.Layout(gtx)
+						}
+						return pg.Theme.Body2(components.TruncateString(addressLabel.Label, 30)).Layout(gtx)
+					})
+				})
+			})
+		}))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
 func (pg *ReceivePage) Handle() {
 	gtx := pg.gtx
 	if pg.backdrop.Clicked() {
@@ -332,11 +503,39 @@ func (pg *ReceivePage) Handle() {
 			return
 		}
 
+		pg.generated = append(pg.generated, pg.currentAddress)
 		pg.currentAddress = newAddr
 		pg.generateQRForAddress()
+		pg.loadAddressHistory()
 		pg.isNewAddr = false
 	}
 
+	for i, address := range pg.addressHistory {
+		for pg.historyClickables[i].Clicked() {
+			modal.NewLabelModal(pg.Load, pg.selector.SelectedAccount().WalletID, wallet.LabelAddress, address).
+				Title("Address label").
+				Show()
+		}
+	}
+
+	requestChanged := false
+	for _, editor := range []*widget.Editor{pg.amountEditor.Editor, pg.labelEditor.Editor, pg.messageEditor.Editor} {
+		for _, evt := range editor.Events() {
+			if _, ok := evt.(widget.ChangeEvent); ok {
+				requestChanged = true
+			}
+		}
+	}
+
+	if requestChanged {
+		if _, err := pg.requestedAmount(); err != nil {
+			pg.amountEditor.SetError(err.Error())
+		} else {
+			pg.amountEditor.SetError("")
+		}
+		pg.generateQRForAddress()
+	}
+
 	if pg.infoButton.Button.Clicked() {
 		info := modal.NewInfoModal(pg.Load).
 			Title("Receive DCR").
@@ -351,7 +550,7 @@ func (pg *ReceivePage) Handle() {
 
 	if pg.copy.Clicked() {
 
-		clipboard.WriteOp{Text: pg.currentAddress}.Add(gtx.Ops)
+		clipboard.WriteOp{Text: pg.paymentRequest()}.Add(gtx.Ops)
 
 		pg.copy.Text = "Copied!"
 		pg.copy.Color = pg.Theme.Color.Success
@@ -430,8 +430,9 @@ func (pg *Restore) Handle() {
 					if pg.WL.MultiWallet.LoadedWalletsCount() > 1 {
 						pg.PopWindowPage()
 					} else {
-						pg.WL.Wallet.SetupListeners()
-						pg.ChangeWindowPage(NewMainPage(pg.Load), false)
+						if setupListeners(pg.Load) {
+							pg.ChangeWindowPage(NewMainPage(pg.Load), false)
+						}
 					}
 
 				}()
@@ -17,6 +17,7 @@ type SecurityToolsPage struct {
 	*load.Load
 	verifyMessage   *decredmaterial.Clickable
 	validateAddress *decredmaterial.Clickable
+	broadcastTx     *decredmaterial.Clickable
 
 	backButton decredmaterial.IconButton
 }
@@ -26,10 +27,12 @@ func NewSecurityToolsPage(l *load.Load) *SecurityToolsPage {
 		Load:            l,
 		verifyMessage:   l.Theme.NewClickable(false),
 		validateAddress: l.Theme.NewClickable(false),
+		broadcastTx:     l.Theme.NewClickable(false),
 	}
 
 	pg.verifyMessage.Radius = decredmaterial.Radius(14)
 	pg.validateAddress.Radius = decredmaterial.Radius(14)
+	pg.broadcastTx.Radius = decredmaterial.Radius(14)
 
 	pg.backButton, _ = components.SubpageHeaderButtons(l)
 
@@ -57,12 +60,17 @@ func (pg *SecurityToolsPage) Layout(gtx layout.Context) layout.Dimensions {
 			Body: func(gtx C) D {
 				return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
 					return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
-						layout.Flexed(.5, pg.message()),
+						layout.Flexed(.33, pg.message()),
 						layout.Rigid(func(gtx C) D {
 							size := image.Point{X: 15, Y: gtx.Constraints.Min.Y}
 							return layout.Dimensions{Size: size}
 						}),
-						layout.Flexed(.5, pg.address()),
+						layout.Flexed(.33, pg.address()),
+						layout.Rigid(func(gtx C) D {
+							size := image.Point{X: 15, Y: gtx.Constraints.Min.Y}
+							return layout.Dimensions{Size: size}
+						}),
+						layout.Flexed(.33, pg.transaction()),
 					)
 				})
 			},
@@ -84,6 +92,12 @@ func (pg *SecurityToolsPage) address() layout.Widget {
 	}
 }
 
+func (pg *SecurityToolsPage) transaction() layout.Widget {
+	return func(gtx C) D {
+		return pg.pageSections(gtx, pg.Icons.SendIcon, pg.broadcastTx, pg.Theme.Body1("Republish Transaction").Layout)
+	}
+}
+
 func (pg *SecurityToolsPage) pageSections(gtx layout.Context, icon *decredmaterial.Image, action *decredmaterial.Clickable, body layout.Widget) layout.Dimensions {
 	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
 		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
@@ -113,6 +127,10 @@ func (pg *SecurityToolsPage) Handle() {
 	if pg.validateAddress.Clicked() {
 		pg.ChangeFragment(NewValidateAddressPage(pg.Load))
 	}
+
+	if pg.broadcastTx.Clicked() {
+		pg.ChangeFragment(NewBroadcastTxPage(pg.Load))
+	}
 }
 
 func (pg *SecurityToolsPage) OnClose() {}
@@ -0,0 +1,108 @@
+package send
+
+import (
+	"gioui.org/layout"
+	"gioui.org/text"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+const contactPickerModalID = "contact_picker_modal"
+
+// contactPickerModal lists the address book contacts and passes the one
+// clicked to contactSelected.
+type contactPickerModal struct {
+	*load.Load
+
+	modal           decredmaterial.Modal
+	contacts        []load.Contact
+	contactList     *decredmaterial.ClickableList
+	cancel          decredmaterial.Button
+	contactSelected func(load.Contact)
+}
+
+func newContactPickerModal(l *load.Load, contactSelected func(load.Contact)) *contactPickerModal {
+	cp := &contactPickerModal{
+		Load:            l,
+		modal:           *l.Theme.ModalFloatTitle(),
+		contactList:     l.Theme.NewClickableList(layout.Vertical),
+		cancel:          l.Theme.OutlineButton("Cancel"),
+		contactSelected: contactSelected,
+	}
+
+	cp.contactList.Radius = decredmaterial.Radius(values.MarginPadding14.V)
+
+	return cp
+}
+
+func (cp *contactPickerModal) ModalID() string {
+	return contactPickerModalID
+}
+
+func (cp *contactPickerModal) Show() {
+	cp.ShowModal(cp)
+}
+
+func (cp *contactPickerModal) Dismiss() {
+	cp.DismissModal(cp)
+}
+
+func (cp *contactPickerModal) OnResume() {
+	contacts, err := cp.WL.Contacts()
+	if err != nil {
+		cp.Toast.NotifyError(err.Error())
+	}
+	cp.contacts = contacts
+}
+
+func (cp *contactPickerModal) OnDismiss() {}
+
+func (cp *contactPickerModal) Handle() {
+	if clicked, selectedItem := cp.contactList.ItemClicked(); clicked {
+		cp.contactSelected(cp.contacts[selectedItem])
+		cp.Dismiss()
+	}
+
+	if cp.cancel.Clicked() || cp.modal.BackdropClicked(true) {
+		cp.Dismiss()
+	}
+}
+
+func (cp *contactPickerModal) Layout(gtx layout.Context) D {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := cp.Theme.H6("Select contact")
+			t.Font.Weight = text.Bold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			if len(cp.contacts) == 0 {
+				txt := cp.Theme.Body1("No contacts yet. Add contacts from the Address Book page.")
+				txt.Color = cp.Theme.Color.Gray2
+				return txt.Layout(gtx)
+			}
+
+			return cp.contactList.Layout(gtx, len(cp.contacts), func(gtx C, i int) D {
+				contact := cp.contacts[i]
+				return layout.UniformInset(values.MarginPadding8).Layout(gtx, func(gtx C) D {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(cp.Theme.Body1(contact.Name).Layout),
+						layout.Rigid(func(gtx C) D {
+							txt := cp.Theme.Body2(contact.Address)
+							txt.Color = cp.Theme.Color.Gray
+							return txt.Layout(gtx)
+						}),
+					)
+				})
+			})
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, cp.cancel.Layout)
+		},
+	}
+
+	return cp.modal.Layout(gtx, w, 850)
+}
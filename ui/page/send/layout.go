@@ -36,6 +36,10 @@ func (pg *Page) initLayoutWidgets() {
 	pg.moreOption.Color = pg.Theme.Color.Gray3
 	pg.moreOption.Inset = layout.UniformInset(values.MarginPadding0)
 
+	pg.addRecipient = pg.Theme.OutlineButton("Add recipient")
+	pg.addRecipient.TextSize = values.TextSize14
+	pg.addRecipient.Inset = layout.UniformInset(values.MarginPadding8)
+
 	pg.retryExchange = pg.Theme.Button("Retry")
 	pg.retryExchange.Background = pg.Theme.Color.Gray1
 	pg.retryExchange.Color = pg.Theme.Color.Primary
@@ -95,6 +99,14 @@ func (pg *Page) getMoreItem() []moreItem {
 				pg.ChangeFragment(NewUTXOPage(pg.Load, pg.sourceAccountSelector.SelectedAccount()))
 			},
 		},
+		{
+			text:   "Import recipients",
+			button: pg.Theme.NewClickable(true),
+			action: func() {
+				pg.moreOptionIsOpen = false
+				pg.showImportRecipientsModal()
+			},
+		},
 		{
 			text:   "Clear all fields",
 			button: pg.Theme.NewClickable(true),
@@ -139,7 +151,10 @@ func (pg *Page) Layout(gtx layout.Context) layout.Dimensions {
 	pageContent := []func(gtx C) D{
 		func(gtx C) D {
 			return pg.pageSections(gtx, "From", false, func(gtx C) D {
-				return pg.sourceAccountSelector.Layout(gtx)
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.sourceAccountSelector.Layout),
+					layout.Rigid(pg.coinControlLayout),
+				)
 			})
 		},
 		func(gtx C) D {
@@ -223,6 +238,26 @@ func (pg *Page) pageSections(gtx layout.Context, title string, showAccountSwitch
 	})
 }
 
+// coinControlLayout shows how many outputs of the source account were
+// selected on the coin control page, if any.
+func (pg *Page) coinControlLayout(gtx layout.Context) layout.Dimensions {
+	account := pg.sourceAccountSelector.SelectedAccount()
+	if account == nil {
+		return layout.Dimensions{}
+	}
+
+	selected := len(pg.SelectedUTXOs(account.WalletID, account.Number))
+	if selected == 0 {
+		return layout.Dimensions{}
+	}
+
+	return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		txt := pg.Theme.Caption(fmt.Sprintf("Spending %d selected outputs (coin control)", selected))
+		txt.Color = pg.Theme.Color.Gray
+		return txt.Layout(gtx)
+	})
+}
+
 func (pg *Page) toSection(gtx layout.Context) layout.Dimensions {
 	return pg.pageSections(gtx, "To", true, func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -233,11 +268,14 @@ func (pg *Page) toSection(gtx layout.Context) layout.Dimensions {
 					if !pg.sendDestination.sendToAddress {
 						return pg.sendDestination.destinationAccountSelector.Layout(gtx)
 					}
-					return pg.sendDestination.destinationAddressEditor.Layout(gtx)
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(pg.sendDestination.destinationAddressEditor.Layout),
+						layout.Rigid(pg.sendDestination.paymentRequestLayout),
+					)
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
-				if pg.exchangeRate != -1 && pg.usdExchangeSet {
+				if pg.exchangeRate != -1 && pg.fiatExchangeSet {
 					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 						layout.Flexed(0.45, func(gtx C) D {
 							return pg.amount.dcrAmountEditor.Layout(gtx)
@@ -250,12 +288,13 @@ func (pg *Page) toSection(gtx layout.Context) layout.Dimensions {
 							})
 						}),
 						layout.Flexed(0.45, func(gtx C) D {
-							return pg.amount.usdAmountEditor.Layout(gtx)
+							return pg.amount.fiatAmountEditor.Layout(gtx)
 						}),
 					)
 				}
 				return pg.amount.dcrAmountEditor.Layout(gtx)
 			}),
+			layout.Rigid(pg.recipientsLayout),
 			layout.Rigid(func(gtx C) D {
 				if pg.exchangeError == "" {
 					return layout.Dimensions{}
@@ -287,11 +326,35 @@ func (pg *Page) toSection(gtx layout.Context) layout.Dimensions {
 	})
 }
 
+func (pg *Page) recipientsLayout(gtx layout.Context) layout.Dimensions {
+	rows := make([]layout.FlexChild, 0, len(pg.recipients)+1)
+	for _, r := range pg.recipients {
+		r := r
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(0.6, r.addressEditor.Layout),
+					layout.Flexed(0.35, func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, r.amountEditor.Layout)
+					}),
+					layout.Rigid(r.removeButton.Layout),
+				)
+			})
+		}))
+	}
+
+	rows = append(rows, layout.Rigid(func(gtx C) D {
+		return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.addRecipient.Layout)
+	}))
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
 func (pg *Page) feeSection(gtx layout.Context) layout.Dimensions {
 	collapsibleHeader := func(gtx C) D {
 		feeText := pg.txFee
-		if pg.exchangeRate != -1 && pg.usdExchangeSet {
-			feeText = fmt.Sprintf("%s (%s)", pg.txFee, pg.txFeeUSD)
+		if pg.exchangeRate != -1 && pg.fiatExchangeSet {
+			feeText = fmt.Sprintf("%s (%s)", pg.txFee, pg.txFeeFiat)
 		}
 		return pg.Theme.Body1(feeText).Layout(gtx)
 	}
@@ -355,8 +418,8 @@ func (pg *Page) balanceSection(gtx layout.Context) layout.Dimensions {
 								}
 								return inset.Layout(gtx, func(gtx C) D {
 									totalCostText := pg.totalCost
-									if pg.exchangeRate != -1 && pg.usdExchangeSet {
-										totalCostText = fmt.Sprintf("%s (%s)", pg.totalCost, pg.totalCostUSD)
+									if pg.exchangeRate != -1 && pg.fiatExchangeSet {
+										totalCostText = fmt.Sprintf("%s (%s)", pg.totalCost, pg.totalCostFiat)
 									}
 									return pg.contentRow(gtx, "Total cost", totalCostText)
 								})
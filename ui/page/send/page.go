@@ -2,7 +2,7 @@ package send
 
 import (
 	"fmt"
-	"strconv"
+	"path/filepath"
 	"strings"
 
 	"gioui.org/io/key"
@@ -15,6 +15,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const (
@@ -36,6 +37,7 @@ type Page struct {
 	sourceAccountSelector *components.AccountSelector
 	sendDestination       *destination
 	amount                *sendAmount
+	recipients            []*recipient
 	keyEvent              chan *key.Event
 
 	backButton    decredmaterial.IconButton
@@ -43,6 +45,7 @@ type Page struct {
 	moreOption    decredmaterial.IconButton
 	retryExchange decredmaterial.Button
 	nextButton    decredmaterial.Button
+	addRecipient  decredmaterial.Button
 
 	txFeeCollapsible *decredmaterial.Collapsible
 	shadowBox        *decredmaterial.Shadow
@@ -52,27 +55,29 @@ type Page struct {
 
 	moreOptionIsOpen bool
 
-	exchangeRate   float64
-	usdExchangeSet bool
-	exchangeError  string
+	exchangeRate     float64
+	exchangeCurrency string
+	fiatExchangeSet  bool
+	exchangeError    string
 
 	*authoredTxData
 }
 
 type authoredTxData struct {
-	txAuthor            *dcrlibwallet.TxAuthor
-	destinationAddress  string
-	destinationAccount  *dcrlibwallet.Account
-	sourceAccount       *dcrlibwallet.Account
-	txFee               string
-	txFeeUSD            string
-	estSignedSize       string
-	totalCost           string
-	totalCostUSD        string
-	balanceAfterSend    string
-	balanceAfterSendUSD string
-	sendAmount          string
-	sendAmountUSD       string
+	txAuthor             *dcrlibwallet.TxAuthor
+	destinationAddress   string
+	destinationAccount   *dcrlibwallet.Account
+	sourceAccount        *dcrlibwallet.Account
+	txFee                string
+	txFeeFiat            string
+	estSignedSize        string
+	totalCost            string
+	totalCostFiat        string
+	balanceAfterSend     string
+	balanceAfterSendFiat string
+	sendAmount           string
+	sendAmountFiat       string
+	outputs              []sendOutput
 }
 
 func NewSendPage(l *load.Load) *Page {
@@ -127,6 +132,13 @@ func NewSendPage(l *load.Load) *Page {
 		pg.validateAndConstructTx()
 	}
 
+	pg.sendDestination.paymentURIPasted = func(uri *wallet.PaymentURI) {
+		if uri.Amount > 0 {
+			pg.amount.sendMax = false
+			pg.amount.setAmount(int64(uri.Amount))
+		}
+	}
+
 	pg.amount.amountChanged = func() {
 		pg.validateAndConstructTx()
 	}
@@ -145,35 +157,63 @@ func (pg *Page) OnResume() {
 	pg.sourceAccountSelector.SelectFirstWalletValidAccount()
 	pg.sendDestination.destinationAddressEditor.Editor.Focus()
 
-	currencyExchangeValue := pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
-	if currencyExchangeValue == components.USDExchangeValue {
-		pg.usdExchangeSet = true
+	currency := pg.WL.ExchangeCurrency()
+	if currency != pg.exchangeCurrency {
+		// rate for the previously selected currency is no longer valid
+		pg.exchangeRate = -1
+		pg.exchangeCurrency = currency
+		pg.amount.setExchangeCurrency(currency)
+	}
+
+	if currency != "" {
+		pg.fiatExchangeSet = true
 		pg.fetchExchangeValue()
 	} else {
-		pg.usdExchangeSet = false
+		pg.fiatExchangeSet = false
+	}
+
+	// the coin control selection may have changed, and outputs in it may
+	// have been spent since it was made.
+	if account := pg.sourceAccountSelector.SelectedAccount(); account != nil {
+		pg.pruneSelectedUTXOs(account)
+	}
+	pg.validateAndConstructTx()
+}
+
+// pruneSelectedUTXOs removes the outputs that have been spent from the coin
+// control selection of account and tells the user if there were any. It
+// returns the number of outputs removed.
+func (pg *Page) pruneSelectedUTXOs(account *dcrlibwallet.Account) int {
+	if len(pg.SelectedUTXOs(account.WalletID, account.Number)) == 0 {
+		return 0
 	}
+
+	utxos, err := pg.WL.Wallet.AccountUnspentOutputs(account.WalletID, account.Number)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return 0
+	}
+
+	removed := pg.PruneSelectedUTXOs(account.WalletID, account.Number, utxos.List)
+	if removed > 0 {
+		pg.Toast.Notify(fmt.Sprintf("%d selected output(s) have been spent and were removed from coin control", removed))
+	}
+	return removed
 }
 
 func (pg *Page) fetchExchangeValue() {
 	pg.exchangeError = ""
 	go func() {
-		var dcrUsdtBittrex load.DCRUSDTBittrex
-		err := load.GetUSDExchangeValue(&dcrUsdtBittrex)
-		if err != nil {
-			pg.exchangeError = err.Error()
-			return
-		}
-
-		exchangeRate, err := strconv.ParseFloat(dcrUsdtBittrex.LastTradeRate, 64)
+		rate, err := pg.WL.Wallet.ExchangeRate(pg.exchangeCurrency)
 		if err != nil {
 			pg.exchangeError = err.Error()
 			return
 		}
 
 		pg.exchangeError = ""
-		pg.exchangeRate = exchangeRate
-		pg.amount.setExchangeRate(exchangeRate)
-		pg.validateAndConstructTx() // convert estimates to usd
+		pg.exchangeRate = rate.Rate
+		pg.amount.setExchangeRate(rate.Rate)
+		pg.validateAndConstructTx() // convert estimates to fiat
 	}()
 }
 
@@ -190,7 +230,14 @@ func (pg *Page) validate() bool {
 	amountIsValid := pg.amount.amountIsValid()
 	addressIsValid := pg.sendDestination.validate()
 
-	validForSending := amountIsValid && addressIsValid
+	recipientsAreValid := true
+	for _, r := range pg.recipients {
+		if !r.validate() {
+			recipientsAreValid = false
+		}
+	}
+
+	validForSending := amountIsValid && addressIsValid && recipientsAreValid
 	pg.nextButton.SetEnabled(validForSending)
 
 	return validForSending
@@ -217,12 +264,54 @@ func (pg *Page) constructTx() {
 		return
 	}
 
+	// outputs selected through coin control become the only inputs of the
+	// transaction
+	var utxoKeys []string
+	var utxoAtom int64
+	for utxoKey, utxo := range pg.SelectedUTXOs(sourceAccount.WalletID, sourceAccount.Number) {
+		utxoKeys = append(utxoKeys, utxoKey)
+		utxoAtom += utxo.UTXO.Amount
+	}
+	if len(utxoKeys) > 0 {
+		err = unsignedTx.UseInputs(utxoKeys)
+		if err != nil {
+			// an output may have been spent since it was selected.
+			if pg.pruneSelectedUTXOs(sourceAccount) > 0 {
+				pg.constructTx()
+				return
+			}
+			pg.feeEstimationError(err.Error())
+			return
+		}
+	}
+
 	err = unsignedTx.AddSendDestination(destinationAddress, amountAtom, sendMax)
 	if err != nil {
 		pg.feeEstimationError(err.Error())
 		return
 	}
 
+	// additional recipients are paid by the same transaction so a single fee
+	// is estimated for the whole batch
+	var recipientsAtom int64
+	recipientOutputs := make([]int64, len(pg.recipients))
+	for i, r := range pg.recipients {
+		address, atoms, err := r.output()
+		if err != nil {
+			pg.feeEstimationError(err.Error())
+			return
+		}
+
+		err = unsignedTx.AddSendDestination(address, atoms, false)
+		if err != nil {
+			pg.feeEstimationError(err.Error())
+			return
+		}
+
+		recipientOutputs[i] = atoms
+		recipientsAtom += atoms
+	}
+
 	feeAndSize, err := unsignedTx.EstimateFeeAndSize()
 	if err != nil {
 		pg.feeEstimationError(err.Error())
@@ -231,10 +320,14 @@ func (pg *Page) constructTx() {
 
 	feeAtom := feeAndSize.Fee.AtomValue
 	if sendMax {
-		amountAtom = sourceAccount.Balance.Spendable - feeAtom
+		spendableAtom := sourceAccount.Balance.Spendable
+		if len(utxoKeys) > 0 {
+			spendableAtom = utxoAtom
+		}
+		amountAtom = spendableAtom - feeAtom - recipientsAtom
 	}
 
-	totalSendingAmount := dcrutil.Amount(amountAtom + feeAtom)
+	totalSendingAmount := dcrutil.Amount(amountAtom + recipientsAtom + feeAtom)
 	balanceAfterSend := dcrutil.Amount(sourceAccount.Balance.Spendable - int64(totalSendingAmount))
 
 	// populate display data
@@ -242,24 +335,41 @@ func (pg *Page) constructTx() {
 	pg.estSignedSize = fmt.Sprintf("%d bytes", feeAndSize.EstimatedSignedSize)
 	pg.totalCost = totalSendingAmount.String()
 	pg.balanceAfterSend = balanceAfterSend.String()
-	pg.sendAmount = dcrutil.Amount(amountAtom).String()
+	pg.sendAmount = dcrutil.Amount(amountAtom + recipientsAtom).String()
 	pg.destinationAddress = destinationAddress
 	pg.destinationAccount = destinationAccount
 	pg.sourceAccount = sourceAccount
 
+	pg.outputs = []sendOutput{{
+		address: destinationAddress,
+		account: destinationAccount,
+		amount:  dcrutil.Amount(amountAtom).String(),
+	}}
+	for i, r := range pg.recipients {
+		pg.outputs = append(pg.outputs, sendOutput{
+			address: strings.TrimSpace(r.addressEditor.Editor.Text()),
+			amount:  dcrutil.Amount(recipientOutputs[i]).String(),
+		})
+	}
+
 	if sendMax {
 		// TODO: this workaround ignores the change events from the
 		// amount input to avoid construct tx cycle.
 		pg.amount.setAmount(amountAtom)
 	}
 
-	if pg.exchangeRate != -1 && pg.usdExchangeSet {
-		pg.txFeeUSD = fmt.Sprintf("$%.4f", load.DCRToUSD(pg.exchangeRate, feeAndSize.Fee.DcrValue))
-		pg.totalCostUSD = load.FormatUSDBalance(pg.Printer, load.DCRToUSD(pg.exchangeRate, totalSendingAmount.ToCoin()))
-		pg.balanceAfterSendUSD = load.FormatUSDBalance(pg.Printer, load.DCRToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
+	if pg.exchangeRate != -1 && pg.fiatExchangeSet {
+		pg.txFeeFiat = load.FormatFiat(pg.Printer, pg.exchangeCurrency, load.DCRToFiat(pg.exchangeRate, feeAndSize.Fee.DcrValue), 4)
+		pg.totalCostFiat = load.FormatFiatBalance(pg.Printer, pg.exchangeCurrency, load.DCRToFiat(pg.exchangeRate, totalSendingAmount.ToCoin()))
+		pg.balanceAfterSendFiat = load.FormatFiatBalance(pg.Printer, pg.exchangeCurrency, load.DCRToFiat(pg.exchangeRate, balanceAfterSend.ToCoin()))
+
+		fiatAmount := load.DCRToFiat(pg.exchangeRate, dcrutil.Amount(amountAtom+recipientsAtom).ToCoin())
+		pg.sendAmountFiat = load.FormatFiatBalance(pg.Printer, pg.exchangeCurrency, fiatAmount)
 
-		usdAmount := load.DCRToUSD(pg.exchangeRate, dcrutil.Amount(amountAtom).ToCoin())
-		pg.sendAmountUSD = load.FormatUSDBalance(pg.Printer, usdAmount)
+		pg.outputs[0].amountFiat = load.FormatFiatBalance(pg.Printer, pg.exchangeCurrency, load.DCRToFiat(pg.exchangeRate, dcrutil.Amount(amountAtom).ToCoin()))
+		for i := range pg.recipients {
+			pg.outputs[i+1].amountFiat = load.FormatFiatBalance(pg.Printer, pg.exchangeCurrency, load.DCRToFiat(pg.exchangeRate, dcrutil.Amount(recipientOutputs[i]).ToCoin()))
+		}
 	}
 
 	pg.txAuthor = unsignedTx
@@ -281,20 +391,71 @@ func (pg *Page) feeEstimationError(err string) {
 func (pg *Page) clearEstimates() {
 	pg.txAuthor = nil
 	pg.txFee = " - "
-	pg.txFeeUSD = " - "
+	pg.txFeeFiat = " - "
 	pg.estSignedSize = " - "
 	pg.totalCost = " - "
-	pg.totalCostUSD = " - "
+	pg.totalCostFiat = " - "
 	pg.balanceAfterSend = " - "
-	pg.balanceAfterSendUSD = " - "
+	pg.balanceAfterSendFiat = " - "
 	pg.sendAmount = " - "
-	pg.sendAmountUSD = " - "
+	pg.sendAmountFiat = " - "
+	pg.outputs = nil
 }
 
 func (pg *Page) resetFields() {
 	pg.sendDestination.clearAddressInput()
 
 	pg.amount.resetFields()
+	pg.recipients = nil
+}
+
+// importRecipients fills the recipients list from a CSV file of
+// "address,amount" rows. The first row is used as the "To" destination if
+// no address has been entered there yet.
+func (pg *Page) importRecipients(path string) error {
+	rows, err := readRecipientsCSV(path)
+	if err != nil {
+		return err
+	}
+
+	if pg.sendDestination.sendToAddress && strings.TrimSpace(pg.sendDestination.destinationAddressEditor.Editor.Text()) == "" {
+		pg.sendDestination.destinationAddressEditor.Editor.SetText(rows[0].address)
+		pg.amount.sendMax = false
+		pg.amount.dcrAmountEditor.Editor.SetText(rows[0].amount)
+		pg.amount.validateDCRAmount()
+		rows = rows[1:]
+	}
+
+	pg.recipients = nil
+	for _, row := range rows {
+		r := newRecipient(pg.Load)
+		r.addressEditor.Editor.SetText(row.address)
+		r.amountEditor.Editor.SetText(row.amount)
+		pg.recipients = append(pg.recipients, r)
+	}
+
+	pg.validateAndConstructTx()
+	return nil
+}
+
+func (pg *Page) showImportRecipientsModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint("CSV file of address,amount rows").
+		SetText(filepath.Join(pg.WL.Wallet.Root, "recipients.csv")).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		PositiveButton("Import", func(path string, tim *modal.TextInputModal) bool {
+			err := pg.importRecipients(path)
+			if err != nil {
+				tim.SetError(err.Error())
+				tim.IsLoading = false
+				return false
+			}
+			return true
+		})
+
+	textModal.Title("Import recipients").
+		NegativeButton("Cancel", func() {})
+	textModal.Show()
 }
 
 func (pg *Page) Handle() {
@@ -318,6 +479,29 @@ func (pg *Page) Handle() {
 		pg.moreOptionIsOpen = !pg.moreOptionIsOpen
 	}
 
+	for pg.addRecipient.Clicked() {
+		pg.recipients = append(pg.recipients, newRecipient(pg.Load))
+		pg.validateAndConstructTx()
+	}
+
+	recipientsChanged := false
+	for i := 0; i < len(pg.recipients); i++ {
+		r := pg.recipients[i]
+		if r.changed() {
+			recipientsChanged = true
+		}
+
+		if r.removeButton.Button.Clicked() {
+			pg.recipients = append(pg.recipients[:i], pg.recipients[i+1:]...)
+			recipientsChanged = true
+			i--
+		}
+	}
+
+	if recipientsChanged {
+		pg.validateAndConstructTx()
+	}
+
 	for pg.retryExchange.Clicked() {
 		pg.fetchExchangeValue()
 	}
@@ -325,9 +509,11 @@ func (pg *Page) Handle() {
 	for pg.nextButton.Clicked() {
 		if pg.txAuthor != nil {
 			confirmTxModal := newSendConfirmModal(pg.Load, pg.authoredTxData)
-			confirmTxModal.exchangeRateSet = pg.exchangeRate != -1 && pg.usdExchangeSet
+			confirmTxModal.exchangeRateSet = pg.exchangeRate != -1 && pg.fiatExchangeSet
 
 			confirmTxModal.txSent = func() {
+				// the selected outputs have been spent
+				pg.ClearSelectedUTXOs(pg.sourceAccount.WalletID, pg.sourceAccount.Number)
 				pg.resetFields()
 				pg.clearEstimates()
 			}
@@ -346,9 +532,8 @@ func (pg *Page) Handle() {
 		}
 	}
 
-	currencyValue := pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
-	if currencyValue == components.USDExchangeValue {
-		decredmaterial.SwitchEditors(pg.keyEvent, pg.sendDestination.destinationAddressEditor.Editor, pg.amount.dcrAmountEditor.Editor, pg.amount.usdAmountEditor.Editor)
+	if pg.fiatExchangeSet {
+		decredmaterial.SwitchEditors(pg.keyEvent, pg.sendDestination.destinationAddressEditor.Editor, pg.amount.dcrAmountEditor.Editor, pg.amount.fiatAmountEditor.Editor)
 	} else {
 		decredmaterial.SwitchEditors(pg.keyEvent, pg.sendDestination.destinationAddressEditor.Editor, pg.amount.dcrAmountEditor.Editor)
 	}
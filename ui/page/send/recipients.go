@@ -0,0 +1,159 @@
+package send
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+// recipient is an additional address and amount paid by the transaction
+// on top of the destination entered in the "To" section.
+type recipient struct {
+	*load.Load
+
+	addressEditor decredmaterial.Editor
+	amountEditor  decredmaterial.Editor
+	removeButton  decredmaterial.IconButton
+}
+
+// sendOutput is a single payment shown in the confirm modal breakdown.
+type sendOutput struct {
+	address    string
+	account    *dcrlibwallet.Account
+	amount     string
+	amountFiat string
+}
+
+func newRecipient(l *load.Load) *recipient {
+	r := &recipient{
+		Load:          l,
+		addressEditor: l.Theme.Editor(new(widget.Editor), "Address"),
+		amountEditor:  l.Theme.Editor(new(widget.Editor), "Amount (DCR)"),
+		removeButton:  l.Theme.PlainIconButton(l.Icons.ContentClear),
+	}
+
+	r.addressEditor.Editor.SingleLine = true
+	r.amountEditor.Editor.SingleLine = true
+	r.removeButton.Color = l.Theme.Color.Gray3
+	r.removeButton.Size = values.MarginPadding20
+
+	return r
+}
+
+// output returns the address and amount in atoms of the recipient.
+func (r *recipient) output() (string, int64, error) {
+	address := strings.TrimSpace(r.addressEditor.Editor.Text())
+	if !r.WL.MultiWallet.IsAddressValid(address) {
+		return "", 0, errors.New("invalid address")
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(r.amountEditor.Editor.Text()), 64)
+	if err != nil || amount <= 0 {
+		return "", 0, errors.New(invalidAmountErr)
+	}
+
+	return address, dcrlibwallet.AmountAtom(amount), nil
+}
+
+// validate sets the editor errors of the recipient and returns true if both
+// the address and the amount are valid.
+func (r *recipient) validate() bool {
+	address := strings.TrimSpace(r.addressEditor.Editor.Text())
+	addressValid := r.WL.MultiWallet.IsAddressValid(address)
+	if address == "" || addressValid {
+		r.addressEditor.SetError("")
+	} else {
+		r.addressEditor.SetError("Invalid address")
+	}
+
+	amount := strings.TrimSpace(r.amountEditor.Editor.Text())
+	value, err := strconv.ParseFloat(amount, 64)
+	amountValid := err == nil && value > 0
+	if amount == "" || amountValid {
+		r.amountEditor.SetError("")
+	} else {
+		r.amountEditor.SetError(invalidAmountErr)
+	}
+
+	return addressValid && amountValid
+}
+
+// changed returns true if the text of either editor changed.
+func (r *recipient) changed() bool {
+	changed := false
+	for _, editor := range []*widget.Editor{r.addressEditor.Editor, r.amountEditor.Editor} {
+		for _, evt := range editor.Events() {
+			if _, ok := evt.(widget.ChangeEvent); ok {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// csvRecipient is a row of a recipients CSV file.
+type csvRecipient struct {
+	address string
+	amount  string
+}
+
+// readRecipientsCSV reads "address,amount" rows from the file at path. The
+// amount is in DCR. A first row whose amount is not a number is treated as
+// a header and skipped.
+func readRecipientsCSV(path string) ([]csvRecipient, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var recipients []csvRecipient
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected address,amount", line)
+		}
+
+		address, amount := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if _, err := strconv.ParseFloat(amount, 64); err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: invalid amount %q", line, amount)
+		}
+
+		recipients = append(recipients, csvRecipient{address: address, amount: amount})
+	}
+
+	if len(recipients) == 0 {
+		return nil, errors.New("no recipients in file")
+	}
+
+	return recipients, nil
+}
@@ -0,0 +1,83 @@
+package send
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadRecipientsCSV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recipients")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		csv     string
+		want    []csvRecipient
+		wantErr bool
+	}{{
+		name: "no header",
+		csv:  "TsAddr1,1.5\nTsAddr2,0.25\n",
+		want: []csvRecipient{{"TsAddr1", "1.5"}, {"TsAddr2", "0.25"}},
+	}, {
+		name: "header",
+		csv:  "address,amount\nTsAddr1,1\n",
+		want: []csvRecipient{{"TsAddr1", "1"}},
+	}, {
+		name: "blank lines and spaces",
+		csv:  "\naddress, amount\n\n TsAddr1 , 2\n\n\nTsAddr2,3\n\n",
+		want: []csvRecipient{{"TsAddr1", "2"}, {"TsAddr2", "3"}},
+	}, {
+		name: "extra columns",
+		csv:  "TsAddr1,1,rent\n",
+		want: []csvRecipient{{"TsAddr1", "1"}},
+	}, {
+		name:    "invalid amount after the first line",
+		csv:     "TsAddr1,1\nTsAddr2,two\n",
+		wantErr: true,
+	}, {
+		name:    "missing amount",
+		csv:     "TsAddr1\n",
+		wantErr: true,
+	}, {
+		name:    "header only",
+		csv:     "address,amount\n",
+		wantErr: true,
+	}, {
+		name:    "empty file",
+		csv:     "\n\n",
+		wantErr: true,
+	}}
+
+	for i, test := range tests {
+		path := filepath.Join(dir, fmt.Sprintf("recipients%d.csv", i))
+		if err := ioutil.WriteFile(path, []byte(test.csv), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		recipients, err := readRecipientsCSV(path)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %v", test.name, recipients)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(recipients, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, recipients, test.want)
+		}
+	}
+
+	if _, err := readRecipientsCSV(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
@@ -18,13 +18,13 @@ const invalidAmountErr = "Invalid amount" //TODO: use localized strings
 type sendAmount struct {
 	*load.Load
 
-	dcrAmountEditor decredmaterial.Editor
-	usdAmountEditor decredmaterial.Editor
+	dcrAmountEditor  decredmaterial.Editor
+	fiatAmountEditor decredmaterial.Editor
 
-	sendMax               bool
-	dcrSendMaxChangeEvent bool
-	usdSendMaxChangeEvent bool
-	amountChanged         func()
+	sendMax                bool
+	dcrSendMaxChangeEvent  bool
+	fiatSendMaxChangeEvent bool
+	amountChanged          func()
 
 	amountErrorText string
 
@@ -47,21 +47,27 @@ func newSendAmount(l *load.Load) *sendAmount {
 	sa.dcrAmountEditor.CustomButton.Text = "Max"
 	sa.dcrAmountEditor.CustomButton.CornerRadius = values.MarginPadding0
 
-	sa.usdAmountEditor = l.Theme.Editor(new(widget.Editor), "Amount (USD)")
-	sa.usdAmountEditor.Editor.SetText("")
-	sa.usdAmountEditor.HasCustomButton = true
-	sa.usdAmountEditor.Editor.SingleLine = true
-	sa.usdAmountEditor.CustomButton.Background = l.Theme.Color.Gray
-	sa.usdAmountEditor.CustomButton.Inset = layout.UniformInset(values.MarginPadding2)
-	sa.usdAmountEditor.CustomButton.Text = "Max"
-	sa.usdAmountEditor.CustomButton.CornerRadius = values.MarginPadding0
+	sa.fiatAmountEditor = l.Theme.Editor(new(widget.Editor), "Amount")
+	sa.fiatAmountEditor.Editor.SetText("")
+	sa.fiatAmountEditor.HasCustomButton = true
+	sa.fiatAmountEditor.Editor.SingleLine = true
+	sa.fiatAmountEditor.CustomButton.Background = l.Theme.Color.Gray
+	sa.fiatAmountEditor.CustomButton.Inset = layout.UniformInset(values.MarginPadding2)
+	sa.fiatAmountEditor.CustomButton.Text = "Max"
+	sa.fiatAmountEditor.CustomButton.CornerRadius = values.MarginPadding0
 
 	return sa
 }
 
 func (sa *sendAmount) setExchangeRate(exchangeRate float64) {
 	sa.exchangeRate = exchangeRate
-	sa.validateDCRAmount() // convert dcr input to usd
+	sa.validateDCRAmount() // convert dcr input to fiat
+}
+
+func (sa *sendAmount) setExchangeCurrency(currency string) {
+	sa.exchangeRate = -1
+	sa.fiatAmountEditor.Hint = fmt.Sprintf("Amount (%s)", currency)
+	sa.fiatAmountEditor.Editor.SetText("")
 }
 
 func (sa *sendAmount) setAmount(amount int64) {
@@ -71,10 +77,10 @@ func (sa *sendAmount) setAmount(amount int64) {
 	sa.dcrAmountEditor.Editor.SetText(fmt.Sprintf("%.8f", dcrutil.Amount(amount).ToCoin()))
 
 	if sa.exchangeRate != -1 {
-		usdAmount := load.DCRToUSD(sa.exchangeRate, dcrutil.Amount(amount).ToCoin())
+		fiatAmount := load.DCRToFiat(sa.exchangeRate, dcrutil.Amount(amount).ToCoin())
 
-		sa.usdSendMaxChangeEvent = true
-		sa.usdAmountEditor.Editor.SetText(fmt.Sprintf("%.2f", usdAmount))
+		sa.fiatSendMaxChangeEvent = true
+		sa.fiatAmountEditor.Editor.SetText(fmt.Sprintf("%.2f", fiatAmount))
 
 	}
 }
@@ -102,30 +108,30 @@ func (sa *sendAmount) validateDCRAmount() {
 	if sa.inputsNotEmpty(sa.dcrAmountEditor.Editor) {
 		dcrAmount, err := strconv.ParseFloat(sa.dcrAmountEditor.Editor.Text(), 64)
 		if err != nil {
-			// empty usd input
-			sa.usdAmountEditor.Editor.SetText("")
+			// empty fiat input
+			sa.fiatAmountEditor.Editor.SetText("")
 			sa.amountErrorText = invalidAmountErr
 			// todo: invalid decimal places error
 			return
 		}
 
 		if sa.exchangeRate != -1 {
-			usdAmount := load.DCRToUSD(sa.exchangeRate, dcrAmount)
-			sa.usdAmountEditor.Editor.SetText(fmt.Sprintf("%.2f", usdAmount)) // 2 decimal places
+			fiatAmount := load.DCRToFiat(sa.exchangeRate, dcrAmount)
+			sa.fiatAmountEditor.Editor.SetText(fmt.Sprintf("%.2f", fiatAmount)) // 2 decimal places
 		}
 
 		return
 	}
 
-	// empty usd input since this is empty
-	sa.usdAmountEditor.Editor.SetText("")
+	// empty fiat input since this is empty
+	sa.fiatAmountEditor.Editor.SetText("")
 }
 
-// validateUSDAmount is called when usd text changes
-func (sa *sendAmount) validateUSDAmount() bool {
+// validateFiatAmount is called when fiat text changes
+func (sa *sendAmount) validateFiatAmount() bool {
 	sa.amountErrorText = ""
-	if sa.inputsNotEmpty(sa.usdAmountEditor.Editor) {
-		usdAmount, err := strconv.ParseFloat(sa.usdAmountEditor.Editor.Text(), 64)
+	if sa.inputsNotEmpty(sa.fiatAmountEditor.Editor) {
+		fiatAmount, err := strconv.ParseFloat(sa.fiatAmountEditor.Editor.Text(), 64)
 		if err != nil {
 			// empty dcr input
 			sa.dcrAmountEditor.Editor.SetText("")
@@ -134,7 +140,7 @@ func (sa *sendAmount) validateUSDAmount() bool {
 		}
 
 		if sa.exchangeRate != -1 {
-			dcrAmount := load.USDToDCR(sa.exchangeRate, usdAmount)
+			dcrAmount := load.FiatToDCR(sa.exchangeRate, fiatAmount)
 			sa.dcrAmountEditor.Editor.SetText(fmt.Sprintf("%.8f", dcrAmount)) // 8 decimal places
 		}
 
@@ -168,7 +174,7 @@ func (sa *sendAmount) resetFields() {
 func (sa *sendAmount) clearAmount() {
 	sa.amountErrorText = ""
 	sa.dcrAmountEditor.Editor.SetText("")
-	sa.usdAmountEditor.Editor.SetText("")
+	sa.fiatAmountEditor.Editor.SetText("")
 }
 
 func (sa *sendAmount) handle() {
@@ -176,18 +182,18 @@ func (sa *sendAmount) handle() {
 
 	if sa.amountErrorText != "" {
 		sa.dcrAmountEditor.LineColor, sa.dcrAmountEditor.TitleLabelColor = sa.Theme.Color.Danger, sa.Theme.Color.Danger
-		sa.usdAmountEditor.LineColor, sa.usdAmountEditor.TitleLabelColor = sa.Theme.Color.Danger, sa.Theme.Color.Danger
+		sa.fiatAmountEditor.LineColor, sa.fiatAmountEditor.TitleLabelColor = sa.Theme.Color.Danger, sa.Theme.Color.Danger
 	} else {
 		sa.dcrAmountEditor.LineColor, sa.dcrAmountEditor.TitleLabelColor = sa.Theme.Color.Gray1, sa.Theme.Color.Gray3
-		sa.usdAmountEditor.LineColor, sa.usdAmountEditor.TitleLabelColor = sa.Theme.Color.Gray1, sa.Theme.Color.Gray3
+		sa.fiatAmountEditor.LineColor, sa.fiatAmountEditor.TitleLabelColor = sa.Theme.Color.Gray1, sa.Theme.Color.Gray3
 	}
 
 	if sa.sendMax {
 		sa.dcrAmountEditor.CustomButton.Background = sa.Theme.Color.Primary
-		sa.usdAmountEditor.CustomButton.Background = sa.Theme.Color.Primary
+		sa.fiatAmountEditor.CustomButton.Background = sa.Theme.Color.Primary
 	} else {
 		sa.dcrAmountEditor.CustomButton.Background = sa.Theme.Color.Gray
-		sa.usdAmountEditor.CustomButton.Background = sa.Theme.Color.Gray
+		sa.fiatAmountEditor.CustomButton.Background = sa.Theme.Color.Gray
 	}
 
 	for _, evt := range sa.dcrAmountEditor.Editor.Events() {
@@ -206,23 +212,23 @@ func (sa *sendAmount) handle() {
 		}
 	}
 
-	for _, evt := range sa.usdAmountEditor.Editor.Events() {
-		if sa.usdAmountEditor.Editor.Focused() {
+	for _, evt := range sa.fiatAmountEditor.Editor.Events() {
+		if sa.fiatAmountEditor.Editor.Focused() {
 			switch evt.(type) {
 			case widget.ChangeEvent:
-				if sa.usdSendMaxChangeEvent {
-					sa.usdSendMaxChangeEvent = false
+				if sa.fiatSendMaxChangeEvent {
+					sa.fiatSendMaxChangeEvent = false
 					continue
 				}
 				sa.sendMax = false
-				sa.validateUSDAmount()
+				sa.validateFiatAmount()
 				sa.amountChanged()
 			}
 		}
 	}
 
 	for sa.dcrAmountEditor.CustomButton.Clicked() ||
-		sa.usdAmountEditor.CustomButton.Clicked() {
+		sa.fiatAmountEditor.CustomButton.Clicked() {
 		sa.setError("")
 		sa.sendMax = true
 		sa.amountChanged()
@@ -141,7 +141,7 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 								layout.Flexed(1, func(gtx C) D {
 									if scm.exchangeRateSet {
 										return layout.E.Layout(gtx, func(gtx C) D {
-											txt := scm.Theme.Body1(scm.sendAmountUSD)
+											txt := scm.Theme.Body1(scm.sendAmountFiat)
 											txt.Color = scm.Theme.Color.Gray
 											return txt.Layout(gtx)
 										})
@@ -153,6 +153,10 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 					)
 				}),
 				layout.Rigid(func(gtx C) D {
+					if len(scm.outputs) > 1 {
+						return scm.outputsLayout(gtx)
+					}
+
 					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 						layout.Rigid(func(gtx C) D {
 							icon := scm.Icons.NavigationArrowForward
@@ -208,7 +212,7 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 					return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
 						txFeeText := scm.txFee
 						if scm.exchangeRateSet {
-							txFeeText = fmt.Sprintf("%s (%s)", scm.txFee, scm.txFeeUSD)
+							txFeeText = fmt.Sprintf("%s (%s)", scm.txFee, scm.txFeeFiat)
 						}
 
 						return scm.contentRow(gtx, "Fee", txFeeText, "")
@@ -217,7 +221,7 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 				layout.Rigid(func(gtx C) D {
 					totalCostText := scm.totalCost
 					if scm.exchangeRateSet {
-						totalCostText = fmt.Sprintf("%s (%s)", scm.totalCost, scm.totalCostUSD)
+						totalCostText = fmt.Sprintf("%s (%s)", scm.totalCost, scm.totalCostFiat)
 					}
 
 					return scm.contentRow(gtx, "Total cost", totalCostText, "")
@@ -274,6 +278,32 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 	return scm.modal.Layout(gtx, w, 900)
 }
 
+// outputsLayout lists the address and amount of every output of a
+// transaction with more than one recipient.
+func (scm *sendConfirmModal) outputsLayout(gtx layout.Context) layout.Dimensions {
+	rows := make([]layout.FlexChild, len(scm.outputs))
+	for i, output := range scm.outputs {
+		output := output
+		rows[i] = layout.Rigid(func(gtx C) D {
+			destination := output.address
+			if output.account != nil {
+				destination = output.account.Name
+			}
+
+			amount := output.amount
+			if scm.exchangeRateSet {
+				amount = fmt.Sprintf("%s (%s)", output.amount, output.amountFiat)
+			}
+
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+				return scm.contentRow(gtx, destination, amount, "")
+			})
+		})
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
 func (scm *sendConfirmModal) contentRow(gtx layout.Context, leftValue, rightValue, walletName string) layout.Dimensions {
 	return layout.Flex{}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
@@ -4,22 +4,32 @@ import (
 	"fmt"
 	"strings"
 
+	"gioui.org/layout"
 	"gioui.org/widget"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type destination struct {
 	*load.Load
 
 	addressChanged             func()
+	paymentURIPasted           func(uri *wallet.PaymentURI)
 	destinationAddressEditor   decredmaterial.Editor
 	destinationAccountSelector *components.AccountSelector
 
 	sendToAddress bool
 	accountSwitch *decredmaterial.SwitchButtonText
+
+	// paymentRequest is the payment URI the address was pasted from. Its
+	// label can be saved as a contact for the address.
+	paymentRequest *wallet.PaymentURI
+	contactSaved   bool
+	saveContact    decredmaterial.Button
 }
 
 func newSendDestination(l *load.Load) *destination {
@@ -30,6 +40,15 @@ func newSendDestination(l *load.Load) *destination {
 	dst.destinationAddressEditor = l.Theme.Editor(new(widget.Editor), "Address")
 	dst.destinationAddressEditor.Editor.SingleLine = true
 	dst.destinationAddressEditor.Editor.SetText("")
+	dst.destinationAddressEditor.HasCustomButton = true
+	dst.destinationAddressEditor.CustomButton.Background = l.Theme.Color.Gray
+	dst.destinationAddressEditor.CustomButton.Inset = layout.UniformInset(values.MarginPadding2)
+	dst.destinationAddressEditor.CustomButton.Text = "Contacts"
+	dst.destinationAddressEditor.CustomButton.CornerRadius = values.MarginPadding0
+
+	dst.saveContact = l.Theme.OutlineButton("Save as contact")
+	dst.saveContact.TextSize = values.TextSize14
+	dst.saveContact.Inset = layout.UniformInset(values.MarginPadding0)
 
 	dst.accountSwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{{Text: "Address"}, {Text: "My account"}})
 
@@ -106,6 +125,34 @@ func (dst *destination) validate() bool {
 func (dst *destination) clearAddressInput() {
 	dst.destinationAddressEditor.SetError("")
 	dst.destinationAddressEditor.Editor.SetText("")
+	dst.paymentRequest = nil
+}
+
+// parsePaymentURI replaces a decred: URI entered in the address editor with
+// the address it contains and passes the URI to paymentURIPasted so the
+// requested amount can be filled in. It returns false if the editor does
+// not hold a payment URI.
+func (dst *destination) parsePaymentURI() bool {
+	text := dst.destinationAddressEditor.Editor.Text()
+	if !wallet.IsPaymentURI(text) {
+		return false
+	}
+
+	uri, err := wallet.ParsePaymentURI(text)
+	if err != nil {
+		dst.destinationAddressEditor.SetError(err.Error())
+		return true
+	}
+
+	dst.destinationAddressEditor.Editor.SetText(uri.Address)
+	dst.destinationAddressEditor.Editor.SetCaret(len(uri.Address), len(uri.Address))
+	dst.paymentRequest = uri
+	_, dst.contactSaved = dst.WL.ContactForAddress(uri.Address)
+	if dst.paymentURIPasted != nil {
+		dst.paymentURIPasted(uri)
+	}
+	dst.addressChanged()
+	return true
 }
 
 func (dst *destination) handle() {
@@ -115,12 +162,69 @@ func (dst *destination) handle() {
 		dst.addressChanged()
 	}
 
+	if dst.destinationAddressEditor.CustomButton.Clicked() {
+		newContactPickerModal(dst.Load, func(contact load.Contact) {
+			dst.destinationAddressEditor.Editor.SetText(contact.Address)
+			dst.destinationAddressEditor.Editor.SetCaret(len(contact.Address), len(contact.Address))
+			dst.addressChanged()
+		}).Show()
+	}
+
 	for _, evt := range dst.destinationAddressEditor.Editor.Events() {
 		if dst.destinationAddressEditor.Editor.Focused() {
 			switch evt.(type) {
 			case widget.ChangeEvent:
+				if dst.parsePaymentURI() {
+					continue
+				}
+				if dst.paymentRequest != nil && strings.TrimSpace(dst.destinationAddressEditor.Editor.Text()) != dst.paymentRequest.Address {
+					dst.paymentRequest = nil
+				}
 				dst.addressChanged()
 			}
 		}
 	}
+
+	if dst.saveContact.Clicked() && dst.paymentRequest != nil {
+		err := dst.WL.AddContact(load.Contact{Name: dst.paymentRequest.Label, Address: dst.paymentRequest.Address})
+		if err != nil {
+			dst.Toast.NotifyError(err.Error())
+		} else {
+			dst.contactSaved = true
+			dst.Toast.Notify("Contact saved")
+		}
+	}
+}
+
+// paymentRequestLayout shows the label and message of the payment URI the
+// address was pasted from, which are not part of the transaction.
+func (dst *destination) paymentRequestLayout(gtx C) D {
+	uri := dst.paymentRequest
+	if uri == nil || (uri.Label == "" && uri.Message == "") {
+		return D{}
+	}
+
+	caption := func(title, text string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			if text == "" {
+				return D{}
+			}
+			txt := dst.Theme.Caption(fmt.Sprintf("%s: %s", title, text))
+			txt.Color = dst.Theme.Color.Gray
+			return txt.Layout(gtx)
+		})
+	}
+
+	return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			caption("Payment request label", uri.Label),
+			caption("Message", uri.Message),
+			layout.Rigid(func(gtx C) D {
+				if uri.Label == "" || dst.contactSaved {
+					return D{}
+				}
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, dst.saveContact.Layout)
+			}),
+		)
+	})
 }
@@ -2,6 +2,7 @@ package send
 
 import (
 	"fmt"
+	"sort"
 
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
@@ -12,6 +13,7 @@ import (
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
@@ -21,18 +23,29 @@ import (
 
 const UTXOPageID = "unspentTransactionOutput"
 
+// UTXO list sort orders, in the order of the sort dropdown items.
+const (
+	sortUTXOAmountDesc = iota
+	sortUTXOAmountAsc
+	sortUTXONewest
+	sortUTXOOldest
+	sortUTXOMostConfirmations
+	sortUTXOFewestConfirmations
+)
+
 type UTXOPage struct {
 	*load.Load
-	utxoListContainer      layout.List
-	txAuthor               *dcrlibwallet.TxAuthor
-	backButton             decredmaterial.IconButton
-	useUTXOButton          decredmaterial.Button
-	unspentOutputs         **wallet.UnspentOutputs
-	unspentOutputsSelected *map[int]map[int32]map[string]*wallet.UnspentOutput
-	checkboxes             []decredmaterial.CheckBoxStyle
-	copyButtons            []decredmaterial.IconButton
-	selectAllChexBox       decredmaterial.CheckBoxStyle
-	separator              decredmaterial.Line
+	utxoListContainer layout.List
+	backButton        decredmaterial.IconButton
+	useUTXOButton     decredmaterial.Button
+	consolidateButton decredmaterial.Button
+	sortDropDown      *decredmaterial.DropDown
+	unspentOutputs    []*wallet.UnspentOutput
+	checkboxes        []decredmaterial.CheckBoxStyle
+	copyButtons       []decredmaterial.IconButton
+	labelButtons      []*decredmaterial.Clickable
+	selectAllChexBox  decredmaterial.CheckBoxStyle
+	separator         decredmaterial.Line
 
 	txnFee            string
 	txnAmount         string
@@ -40,25 +53,36 @@ type UTXOPage struct {
 
 	selectedWalletID  int
 	selectedAccountID int32
+
+	// consolidated receives the number of outputs consolidated so the
+	// outputs are reloaded on the UI goroutine.
+	consolidated chan int
 }
 
 func NewUTXOPage(l *load.Load, account *dcrlibwallet.Account) *UTXOPage {
 	pg := &UTXOPage{
-		Load:           l,
-		unspentOutputs: &l.WL.UnspentOutputs,
+		Load: l,
 		utxoListContainer: layout.List{
 			Axis: layout.Vertical,
 		},
-		// txAuthor:               &l.WL.TxAuthor,
-		unspentOutputsSelected: &l.SelectedUTXO,
-		selectAllChexBox:       l.Theme.CheckBox(new(widget.Bool), ""),
-		separator:              l.Theme.Separator(),
-		selectedWalletID:       account.WalletID,
-		selectedAccountID:      account.Number,
+		selectAllChexBox:  l.Theme.CheckBox(new(widget.Bool), ""),
+		separator:         l.Theme.Separator(),
+		selectedWalletID:  account.WalletID,
+		selectedAccountID: account.Number,
+		consolidated:      make(chan int, 1),
 	}
 
 	pg.backButton, _ = components.SubpageHeaderButtons(pg.Load)
 	pg.useUTXOButton = l.Theme.Button("OK")
+	pg.consolidateButton = l.Theme.OutlineButton("Consolidate")
+	pg.sortDropDown = l.Theme.DropDown([]decredmaterial.DropDownItem{
+		{Text: "Largest amount"},
+		{Text: "Smallest amount"},
+		{Text: values.String(values.StrNewest)},
+		{Text: values.String(values.StrOldest)},
+		{Text: "Most confirmations"},
+		{Text: "Fewest confirmations"},
+	}, 1)
 
 	return pg
 }
@@ -68,46 +92,115 @@ func (pg *UTXOPage) ID() string {
 }
 
 func (pg *UTXOPage) OnResume() {
+	pg.loadUTXOs()
+}
+
+// loadUTXOs fetches the unspent outputs of the account and drops any
+// selected output that has since been spent.
+func (pg *UTXOPage) loadUTXOs() {
+	utxos, err := pg.WL.Wallet.AccountUnspentOutputs(pg.selectedWalletID, pg.selectedAccountID)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	pg.unspentOutputs = utxos.List
+	pg.sortUTXOs()
 
+	pg.PruneSelectedUTXOs(pg.selectedWalletID, pg.selectedAccountID, pg.unspentOutputs)
+
+	pg.checkboxes = make([]decredmaterial.CheckBoxStyle, len(pg.unspentOutputs))
+	pg.copyButtons = make([]decredmaterial.IconButton, len(pg.unspentOutputs))
+	pg.labelButtons = make([]*decredmaterial.Clickable, len(pg.unspentOutputs))
+	for i := range pg.unspentOutputs {
+		pg.checkboxes[i] = pg.Theme.CheckBox(new(widget.Bool), "")
+		pg.labelButtons[i] = pg.Theme.NewClickable(true)
+		icoBtn := pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ContentContentCopy)))
+		icoBtn.Inset, icoBtn.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
+		icoBtn.Background = pg.Theme.Color.LightGray
+		pg.copyButtons[i] = icoBtn
+	}
+	pg.refreshCheckboxes()
+	pg.calculateAmountAndFeeUTXO()
 }
 
-func (pg *UTXOPage) Handle() {
-	if len(pg.checkboxes) != len((*pg.unspentOutputs).List) {
-		pg.checkboxes = make([]decredmaterial.CheckBoxStyle, len((*pg.unspentOutputs).List))
-		pg.copyButtons = make([]decredmaterial.IconButton, len((*pg.unspentOutputs).List))
-
-		for i := 0; i < len((*pg.unspentOutputs).List); i++ {
-			utxo := (*pg.unspentOutputs).List[i]
-			pg.checkboxes[i] = pg.Theme.CheckBox(new(widget.Bool), "")
-			if (*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID][utxo.UTXO.OutputKey] != nil {
-				pg.checkboxes[i].CheckBox.Value = true
-			}
-			icoBtn := pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ContentContentCopy)))
-			icoBtn.Inset, icoBtn.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
-			icoBtn.Background = pg.Theme.Color.LightGray
-			pg.copyButtons[i] = icoBtn
+// sortUTXOs orders the unspent outputs by the option selected in the sort
+// dropdown.
+func (pg *UTXOPage) sortUTXOs() {
+	utxos := pg.unspentOutputs
+	sort.SliceStable(utxos, func(i, j int) bool {
+		a, b := utxos[i].UTXO, utxos[j].UTXO
+		switch pg.sortDropDown.SelectedIndex() {
+		case sortUTXOAmountAsc:
+			return a.Amount < b.Amount
+		case sortUTXONewest:
+			return a.ReceiveTime > b.ReceiveTime
+		case sortUTXOOldest:
+			return a.ReceiveTime < b.ReceiveTime
+		case sortUTXOMostConfirmations:
+			return a.Confirmations > b.Confirmations
+		case sortUTXOFewestConfirmations:
+			return a.Confirmations < b.Confirmations
+		default:
+			return a.Amount > b.Amount
 		}
-		pg.calculateAmountAndFeeUTXO()
+	})
+}
+
+// refreshCheckboxes checks the boxes of the selected unspent outputs.
+func (pg *UTXOPage) refreshCheckboxes() {
+	selected := pg.SelectedUTXOs(pg.selectedWalletID, pg.selectedAccountID)
+	for i, utxo := range pg.unspentOutputs {
+		pg.checkboxes[i].CheckBox.Value = selected[utxo.UTXO.OutputKey] != nil
 	}
+	pg.selectAllChexBox.CheckBox.Value = len(pg.unspentOutputs) > 0 && len(selected) == len(pg.unspentOutputs)
+}
 
+func (pg *UTXOPage) Handle() {
 	if pg.backButton.Button.Clicked() {
 		pg.clearPageData()
 		pg.PopFragment()
 	}
 
-	/*if pg.useUTXOButton.Button.Clicked() {
-		//TODO
-		//pg.ChangePage(send.PageID)
+	if pg.useUTXOButton.Button.Clicked() {
+		pg.PopFragment()
+	}
+
+	for pg.sortDropDown.Changed() {
+		pg.sortUTXOs()
+		pg.refreshCheckboxes()
+	}
+
+	if pg.consolidateButton.Button.Clicked() {
+		pg.showConsolidateModal()
+	}
+
+	select {
+	case count := <-pg.consolidated:
+		pg.Toast.Notify(fmt.Sprintf("%d outputs consolidated", count))
+		pg.ClearSelectedUTXOs(pg.selectedWalletID, pg.selectedAccountID)
+		pg.loadUTXOs()
+	default:
+	}
+
+	for i, btn := range pg.labelButtons {
+		for btn.Clicked() {
+			modal.NewLabelModal(pg.Load, pg.selectedWalletID, wallet.LabelOutput, pg.unspentOutputs[i].UTXO.OutputKey).
+				Title("Output label").
+				Show()
+		}
 	}
-	*/
+
+	selected := pg.SelectedUTXOs(pg.selectedWalletID, pg.selectedAccountID)
+	pg.consolidateButton.SetEnabled(len(selected) > 1)
 
 	if pg.selectAllChexBox.CheckBox.Changed() {
-		for i, utxo := range (*pg.unspentOutputs).List {
+		for i, utxo := range pg.unspentOutputs {
 			if pg.selectAllChexBox.CheckBox.Value {
 				pg.checkboxes[i].CheckBox.Value = true
-				(*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID][utxo.UTXO.OutputKey] = utxo
+				selected[utxo.UTXO.OutputKey] = utxo
 			} else {
-				delete((*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID], utxo.UTXO.OutputKey)
+				delete(selected, utxo.UTXO.OutputKey)
 				pg.checkboxes[i].CheckBox.Value = false
 			}
 		}
@@ -117,27 +210,41 @@ func (pg *UTXOPage) Handle() {
 
 func (pg *UTXOPage) handlerCheckboxes(cb *decredmaterial.CheckBoxStyle, utxo *wallet.UnspentOutput) {
 	if cb.CheckBox.Changed() {
+		selected := pg.SelectedUTXOs(pg.selectedWalletID, pg.selectedAccountID)
 		if cb.CheckBox.Value {
-			(*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID][utxo.UTXO.OutputKey] = utxo
+			selected[utxo.UTXO.OutputKey] = utxo
 		} else {
-			delete((*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID], utxo.UTXO.OutputKey)
+			delete(selected, utxo.UTXO.OutputKey)
 		}
 		pg.calculateAmountAndFeeUTXO()
 	}
 }
 
-func (pg *UTXOPage) calculateAmountAndFeeUTXO() {
-	unsignedTx, err := pg.WL.MultiWallet.NewUnsignedTx(pg.selectedWalletID, pg.selectedAccountID)
-	if err != nil {
-		return
-	}
-
+// selectedUTXOKeys returns the keys and total amount of the selected outputs.
+func (pg *UTXOPage) selectedUTXOKeys() ([]string, int64) {
 	var utxoKeys []string
 	var totalAmount int64
-	for utxoKey, utxo := range (*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID] {
+	for utxoKey, utxo := range pg.SelectedUTXOs(pg.selectedWalletID, pg.selectedAccountID) {
 		utxoKeys = append(utxoKeys, utxoKey)
 		totalAmount += utxo.UTXO.Amount
 	}
+	return utxoKeys, totalAmount
+}
+
+// calculateAmountAndFeeUTXO estimates the fee of spending the selected
+// outputs to a single output of the account.
+func (pg *UTXOPage) calculateAmountAndFeeUTXO() {
+	pg.txnAmount, pg.txnFee, pg.txnAmountAfterFee = "", "", ""
+
+	utxoKeys, totalAmount := pg.selectedUTXOKeys()
+	if len(utxoKeys) == 0 {
+		return
+	}
+
+	unsignedTx, err := pg.WL.MultiWallet.NewUnsignedTx(pg.selectedWalletID, pg.selectedAccountID)
+	if err != nil {
+		return
+	}
 
 	err = unsignedTx.UseInputs(utxoKeys)
 	if err != nil {
@@ -150,8 +257,56 @@ func (pg *UTXOPage) calculateAmountAndFeeUTXO() {
 	pg.txnAmount = dcrutil.Amount(totalAmount).String()
 	pg.txnFee = dcrutil.Amount(feeAndSize.Fee.AtomValue).String()
 	pg.txnAmountAfterFee = dcrutil.Amount(totalAmount - feeAndSize.Fee.AtomValue).String()
+}
+
+// showConsolidateModal asks for the spending password and sends all the
+// selected outputs to a new address of the same account.
+func (pg *UTXOPage) showConsolidateModal() {
+	utxoKeys, _ := pg.selectedUTXOKeys()
+	modal.NewPasswordModal(pg.Load).
+		Title(fmt.Sprintf("Consolidate %d outputs", len(utxoKeys))).
+		NegativeButton("Cancel", func() {}).
+		PositiveButton("Confirm", func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				err := pg.consolidate(utxoKeys, password)
+				if err != nil {
+					pm.SetError(err.Error())
+					pm.SetLoading(false)
+					return
+				}
+				pm.Dismiss()
+				pg.consolidated <- len(utxoKeys)
+				pg.RefreshWindow()
+			}()
+
+			return false
+		}).Show()
+}
+
+func (pg *UTXOPage) consolidate(utxoKeys []string, password string) error {
+	wal := pg.WL.MultiWallet.WalletWithID(pg.selectedWalletID)
+	address, err := wal.NextAddress(pg.selectedAccountID)
+	if err != nil {
+		return err
+	}
+
+	unsignedTx, err := pg.WL.MultiWallet.NewUnsignedTx(pg.selectedWalletID, pg.selectedAccountID)
+	if err != nil {
+		return err
+	}
+
+	err = unsignedTx.UseInputs(utxoKeys)
+	if err != nil {
+		return err
+	}
+
+	err = unsignedTx.AddSendDestination(address, 0, true)
+	if err != nil {
+		return err
+	}
 
-	pg.txAuthor = unsignedTx
+	_, err = unsignedTx.Broadcast([]byte(password))
+	return err
 }
 
 func (pg *UTXOPage) clearPageData() {
@@ -161,65 +316,81 @@ func (pg *UTXOPage) clearPageData() {
 
 func (pg *UTXOPage) Layout(gtx C) D {
 	return components.UniformPadding(gtx, func(gtx C) D {
-		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-			layout.Rigid(func(gtx C) D {
-				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+		return layout.Stack{}.Layout(gtx,
+			layout.Expanded(pg.layoutContent),
+			layout.Expanded(func(gtx C) D {
+				return pg.sortDropDown.Layout(gtx, 0, true)
+			}),
+		)
+	})
+}
+
+func (pg *UTXOPage) layoutContent(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.W.Layout(gtx, pg.backButton.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{
+						Left: values.MarginPadding10,
+						// Top:  values.MarginPaddingMinus10,
+					}.Layout(gtx, pg.Theme.H6("Coin Control").Layout)
+				}),
+			)
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+								layout.Flexed(0.25, func(gtx C) D {
+									utxos := pg.SelectedUTXOs(pg.selectedWalletID, pg.selectedAccountID)
+									return pg.textData(gtx, "Selected:  ", fmt.Sprintf("%d", len(utxos)))
+								}),
+								layout.Flexed(0.25, func(gtx C) D {
+									return pg.textData(gtx, "Amount:  ", pg.txnAmount)
+								}),
+								layout.Flexed(0.25, func(gtx C) D {
+									return pg.textData(gtx, "Fee:  ", pg.txnFee)
+								}),
+								layout.Flexed(0.25, func(gtx C) D {
+									return pg.textData(gtx, "After Fee:  ", pg.txnAmountAfterFee)
+								}),
+							)
+						})
+					}),
+					layout.Rigid(pg.separator.Layout),
 					layout.Rigid(func(gtx C) D {
-						return layout.W.Layout(gtx, pg.backButton.Layout)
+						return pg.utxoRowHeader(gtx)
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.checkboxes) == 0 {
+							return D{}
+						}
+						return pg.utxoListContainer.Layout(gtx, len(pg.unspentOutputs), func(gtx C, index int) D {
+							utxo := pg.unspentOutputs[index]
+							pg.handlerCheckboxes(&pg.checkboxes[index], utxo)
+							return pg.utxoRow(gtx, utxo, index)
+						})
 					}),
 					layout.Rigid(func(gtx C) D {
-						return layout.Inset{
-							Left: values.MarginPadding10,
-							// Top:  values.MarginPaddingMinus10,
-						}.Layout(gtx, pg.Theme.H6("Coin Control").Layout)
+						return layout.Flex{}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.consolidateButton.Layout)
+							}),
+							layout.Flexed(1, func(gtx C) D {
+								gtx.Constraints.Min.X = gtx.Constraints.Max.X
+								return pg.useUTXOButton.Layout(gtx)
+							}),
+						)
 					}),
 				)
-			}),
-			layout.Flexed(1, func(gtx C) D {
-				return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
-					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-						layout.Rigid(func(gtx C) D {
-							return layout.Inset{Bottom: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
-								return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
-									layout.Flexed(0.25, func(gtx C) D {
-										utxos := (*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID]
-										return pg.textData(gtx, "Selected:  ", fmt.Sprintf("%d", len(utxos)))
-									}),
-									layout.Flexed(0.25, func(gtx C) D {
-										return pg.textData(gtx, "Amount:  ", pg.txnAmount)
-									}),
-									layout.Flexed(0.25, func(gtx C) D {
-										return pg.textData(gtx, "Fee:  ", pg.txnFee)
-									}),
-									layout.Flexed(0.25, func(gtx C) D {
-										return pg.textData(gtx, "After Fee:  ", pg.txnAmountAfterFee)
-									}),
-								)
-							})
-						}),
-						layout.Rigid(pg.separator.Layout),
-						layout.Rigid(func(gtx C) D {
-							return pg.utxoRowHeader(gtx)
-						}),
-						layout.Flexed(1, func(gtx C) D {
-							if len(pg.checkboxes) == 0 {
-								return D{}
-							}
-							return pg.utxoListContainer.Layout(gtx, len((*pg.unspentOutputs).List), func(gtx C, index int) D {
-								utxo := (*pg.unspentOutputs).List[index]
-								pg.handlerCheckboxes(&pg.checkboxes[index], utxo)
-								return pg.utxoRow(gtx, utxo, index)
-							})
-						}),
-						layout.Rigid(func(gtx C) D {
-							gtx.Constraints.Min.X = gtx.Constraints.Max.X
-							return pg.useUTXOButton.Layout(gtx)
-						}),
-					)
-				})
-			}),
-		)
-	})
+			})
+		}),
+	)
 }
 
 func (pg *UTXOPage) textData(gtx C, txt, value string) D {
@@ -259,6 +430,12 @@ func (pg *UTXOPage) utxoRowHeader(gtx C) D {
 				txt.Text = "Confirmations"
 				return txt.Layout(gtx)
 			}),
+			layout.Rigid(func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Px(values.MarginPadding150)
+				txt.Text = "Label"
+				txt.Alignment = text.Start
+				return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, txt.Layout)
+			}),
 		)
 	})
 }
@@ -294,6 +471,15 @@ func (pg *UTXOPage) utxoRow(gtx C, data *wallet.UnspentOutput, index int) D {
 			gtx.Constraints.Min.X = gtx.Px(values.MarginPadding100)
 			return txt.Layout(gtx)
 		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return pg.labelButtons[index].Layout(gtx, func(gtx C) D {
+					gtx.Constraints.Min.X = gtx.Px(values.MarginPadding150)
+					gtx.Constraints.Max.X = gtx.Constraints.Min.X
+					return pg.outputLabel(data).Layout(gtx)
+				})
+			})
+		}),
 		layout.Rigid(func(gtx C) D {
 			if pg.copyButtons[index].Button.Clicked() {
 				clipboard.WriteOp{Text: data.UTXO.Addresses}.Add(gtx.Ops)
@@ -303,4 +489,23 @@ func (pg *UTXOPage) utxoRow(gtx C, data *wallet.UnspentOutput, index int) D {
 	)
 }
 
+// outputLabel returns the label of an unspent output, or the label of its
+// address if the output has none.
+func (pg *UTXOPage) outputLabel(data *wallet.UnspentOutput) decredmaterial.Label {
+	outputLabel := pg.WL.Wallet.Label(pg.selectedWalletID, wallet.LabelOutput, data.UTXO.OutputKey)
+	if outputLabel.Label != "" {
+		txt := pg.Theme.Body2(outputLabel.Label)
+		txt.MaxLines = 1
+		return txt
+	}
+
+	txt := pg.Theme.Body2("Add label")
+	if addressLabel := pg.WL.Wallet.Label(pg.selectedWalletID, wallet.LabelAddress, data.UTXO.Addresses); addressLabel.Label != "" {
+		txt.Text = addressLabel.Label
+	}
+	txt.Color = pg.Theme.Color.Gray
+	txt.MaxLines = 1
+	return txt
+}
+
 func (pg *UTXOPage) OnClose() {}
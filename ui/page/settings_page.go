@@ -94,12 +94,15 @@ func NewSettingsPage(l *load.Load) *SettingsPage {
 		Title(values.StrLanguage).
 		UpdateValues(func() {
 			values.SetUserLanguage(pg.wal.ReadStringConfigValueForKey(languagePreferenceKey))
+			pg.RefreshPrinter()
 		})
 	pg.languagePreference = languagePreference
 
 	currencyMap := make(map[string]string)
 	currencyMap[DefaultExchangeValue] = values.StrNone
-	currencyMap[components.USDExchangeValue] = values.StrUsdBittrex
+	for code, strKey := range values.ArrExchangeCurrencies {
+		currencyMap[code] = strKey
+	}
 
 	currencyPreference := preference.NewListPreference(pg.WL.Wallet, pg.Load,
 		dcrlibwallet.CurrencyConversionConfigKey, DefaultExchangeValue,
@@ -182,7 +185,7 @@ func (pg *SettingsPage) general() layout.Widget {
 						title:     values.String(values.StrCurrencyConversion),
 						clickable: pg.currencyPreference.Clickable(),
 						icon:      pg.chevronRightIcon,
-						label:     pg.Theme.Body2(pg.wal.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)),
+						label:     pg.Theme.Body2(pg.currencyConversionLabel()),
 					}
 					return pg.clickableRow(gtx, currencyConversionRow)
 				}),
@@ -201,6 +204,15 @@ func (pg *SettingsPage) general() layout.Widget {
 	}
 }
 
+// currencyConversionLabel returns the name of the selected fiat currency.
+func (pg *SettingsPage) currencyConversionLabel() string {
+	code := pg.WL.ExchangeCurrency()
+	if code == "" {
+		return values.String(values.StrNone)
+	}
+	return values.String(values.ArrExchangeCurrencies[code])
+}
+
 func (pg *SettingsPage) notification() layout.Widget {
 	return func(gtx C) D {
 		return pg.mainSection(gtx, values.String(values.StrNotifications), func(gtx C) D {
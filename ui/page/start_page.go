@@ -1,6 +1,7 @@
 package page
 
 import (
+	"fmt"
 	"os"
 
 	"gioui.org/layout"
@@ -57,7 +58,6 @@ func (sp *startPage) ID() string {
 }
 
 func (sp *startPage) OnResume() {
-	sp.WL.Wallet.InitMultiWallet()
 	sp.WL.MultiWallet = sp.WL.Wallet.GetMultiWallet()
 
 	// refresh theme now that config is available
@@ -113,8 +113,31 @@ func (sp *startPage) openWallets(password string) error {
 }
 
 func (sp *startPage) proceedToMainPage() {
-	sp.WL.Wallet.SetupListeners()
-	sp.ChangeWindowPage(NewMainPage(sp.Load), false)
+	if setupListeners(sp.Load) {
+		sp.ChangeWindowPage(NewMainPage(sp.Load), false)
+	}
+}
+
+// setupListeners sets up the wallet listeners the main page depends on and
+// returns true if they are. Otherwise the error is shown with the option to
+// exit, as the wallets cannot sync or receive transactions without them.
+func setupListeners(l *load.Load) bool {
+	err := l.WL.Wallet.SetupListeners()
+	if err == nil {
+		return true
+	}
+
+	log.Errorf("Error setting up wallet listeners: %v", err)
+	modal.NewInfoModal(l).
+		Title("Could not start the wallets").
+		Body(fmt.Sprintf("The wallets cannot sync or receive transactions: %v. Restart the app to try again.", err)).
+		PositiveButton("Exit", func() {
+			l.WL.MultiWallet.Shutdown()
+			os.Exit(0)
+		}).
+		SetCancelable(false).
+		Show()
+	return false
 }
 
 func (sp *startPage) Handle() {
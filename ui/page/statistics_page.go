@@ -3,7 +3,6 @@ package page
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"gioui.org/layout"
@@ -13,18 +12,13 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
-	"github.com/planetdecred/godcr/wallet"
 )
 
 const StatisticsPageID = "Statistics"
 
 type StatPage struct {
 	*load.Load
-	txs         *wallet.Transactions
-	l           layout.List
-	startupTime string
-	syncStatus  *wallet.SyncStatus
-	netType     string
+	l layout.List
 
 	backButton decredmaterial.IconButton
 }
@@ -32,17 +26,9 @@ type StatPage struct {
 func NewStatPage(l *load.Load) *StatPage {
 	pg := &StatPage{
 		Load: l,
-		txs:  l.WL.Transactions,
 		l: layout.List{
 			Axis: layout.Vertical,
 		},
-		netType: l.WL.Wallet.Net,
-	}
-	pg.syncStatus = l.WL.SyncStatus
-	if pg.netType == "testnet3" {
-		pg.netType = "Testnet"
-	} else {
-		pg.netType = strings.Title(pg.netType)
 	}
 
 	pg.backButton, _ = components.SubpageHeaderButtons(l)
@@ -54,9 +40,7 @@ func (pg *StatPage) ID() string {
 	return StatisticsPageID
 }
 
-func (pg *StatPage) OnResume() {
-	pg.appStartTime()
-}
+func (pg *StatPage) OnResume() {}
 
 func (pg *StatPage) layoutStats(gtx C) D {
 	background := pg.Theme.Color.Surface
@@ -80,29 +64,14 @@ func (pg *StatPage) layoutStats(gtx C) D {
 		}
 	}
 
-	items := []layout.Widget{
-		item("Build", pg.netType+", "+time.Now().Format("2006-01-02")),
-		pg.Theme.Separator().Layout,
-		item("Peers connected", strconv.Itoa(int(pg.syncStatus.ConnectedPeers))),
-		pg.Theme.Separator().Layout,
-		item("Uptime", pg.startupTime),
-		pg.Theme.Separator().Layout,
-		item("Network", pg.netType),
-		pg.Theme.Separator().Layout,
-		item("Best block", fmt.Sprintf("%d", pg.WL.Info.BestBlockHeight)),
-		pg.Theme.Separator().Layout,
-		item("Best block timestamp", time.Unix(pg.WL.Info.BestBlockTime, 0).Format("2006-01-02 03:04:05 -0700")),
-		pg.Theme.Separator().Layout,
-		item("Best block age", pg.WL.Info.LastSyncTime),
-		pg.Theme.Separator().Layout,
-		item("Wallet data directory", pg.WL.WalletDirectory()),
-		pg.Theme.Separator().Layout,
-		item("Wallet data", pg.WL.DataSize()),
-		pg.Theme.Separator().Layout,
-		item("Transactions", fmt.Sprintf("%d", (*pg.txs).Total)),
-		pg.Theme.Separator().Layout,
-		item("Wallets", fmt.Sprintf("%d", len(pg.WL.Info.Wallets))),
+	var items []layout.Widget
+	for i, stat := range appStatistics(pg.Load) {
+		if i > 0 {
+			items = append(items, pg.Theme.Separator().Layout)
+		}
+		items = append(items, item(stat.title, stat.value))
 	}
+	items = append(items, pg.syncSessionItems(inset, item)...)
 
 	return card.Layout(gtx, func(gtx C) D {
 		return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
@@ -113,6 +82,84 @@ func (pg *StatPage) layoutStats(gtx C) D {
 	})
 }
 
+// syncSessionItems returns the rows of the recent sync sessions: charts of
+// their duration and speed, and the stage timings of each session, newest
+// first.
+func (pg *StatPage) syncSessionItems(inset layout.Inset, item func(t, v string) layout.Widget) []layout.Widget {
+	sessions := pg.WL.Wallet.SyncSessions()
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	durations := make([]float32, len(sessions))
+	rates := make([]float32, len(sessions))
+	for i, session := range sessions {
+		durations[i] = float32(session.Duration().Minutes())
+		rates[i] = float32(session.BlocksPerSecond())
+	}
+
+	chart := func(title string, data []float32) layout.Widget {
+		return func(gtx C) D {
+			return inset.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						txt := pg.Theme.Body2(title)
+						return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, txt.Layout)
+					}),
+					layout.Rigid(pg.Theme.BarChart(data).Layout),
+				)
+			})
+		}
+	}
+
+	items := []layout.Widget{
+		pg.Theme.Separator().Layout,
+		func(gtx C) D {
+			return inset.Layout(gtx, pg.Theme.Body1("Recent syncs").Layout)
+		},
+		chart("Sync duration (minutes)", durations),
+		chart("Blocks per second", rates),
+	}
+
+	for i := len(sessions) - 1; i >= 0; i-- {
+		session := sessions[i]
+		title := fmt.Sprintf("%s, %s", session.Start.Format("2006-01-02 15:04"), session.Result)
+		value := fmt.Sprintf("%s, %.0f blocks/s, %d-%d peers", session.Duration().Round(time.Second),
+			session.BlocksPerSecond(), session.MinPeers, session.MaxPeers)
+
+		items = append(items, pg.Theme.Separator().Layout, item(title, value))
+		for _, stage := range session.Stages {
+			stageValue := stage.Duration.Round(time.Second).String()
+			if stage.EndHeight > stage.StartHeight {
+				stageValue += fmt.Sprintf(", blocks %d-%d, %.0f blocks/s", stage.StartHeight, stage.EndHeight, stage.BlocksPerSecond())
+			}
+			items = append(items, pg.syncStageItem(stage.Stage, stageValue))
+		}
+		if session.Error != "" {
+			items = append(items, pg.syncStageItem("error", session.Error))
+		}
+	}
+
+	return items
+}
+
+func (pg *StatPage) syncStageItem(stage, value string) layout.Widget {
+	return func(gtx C) D {
+		inset := layout.Inset{
+			Bottom: values.MarginPadding8,
+			Right:  values.MarginPadding16,
+			Left:   values.MarginPadding30,
+		}
+		l := pg.Theme.Caption(stage)
+		l.Color = pg.Theme.Color.Gray
+		r := pg.Theme.Caption(value)
+		r.Color = pg.Theme.Color.Gray
+		return inset.Layout(gtx, func(gtx C) D {
+			return components.EndToEndRow(gtx, l.Layout, r.Layout)
+		})
+	}
+}
+
 func (pg *StatPage) Layout(gtx layout.Context) layout.Dimensions {
 	container := func(gtx C) D {
 		sp := components.SubPage{
@@ -132,17 +179,38 @@ func (pg *StatPage) Layout(gtx layout.Context) layout.Dimensions {
 	return components.UniformPadding(gtx, container)
 }
 
-func (pg *StatPage) appStartTime() {
-	pg.startupTime = func(t time.Time) string {
-		v := int(time.Since(t).Seconds())
-		h := v / 3600
-		m := (v - h*3600) / 60
-		s := v - h*3600 - m*60
-		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
-	}(pg.WL.Wallet.StartupTime())
+// statistic is a row of the statistics page.
+type statistic struct {
+	title, value string
 }
 
-func (pg *StatPage) Handle() {
-	pg.appStartTime()
+// appStatistics returns the statistics shown on the statistics page. They
+// are also included in the diagnostics bundle.
+func appStatistics(l *load.Load) []statistic {
+	netType := l.WL.Wallet.NetworkParams().DisplayName
+	return []statistic{
+		{"Build", netType + ", " + time.Now().Format("2006-01-02")},
+		{"Peers connected", strconv.Itoa(int(l.WL.SyncStatus.ConnectedPeers))},
+		{"Uptime", uptime(l.WL.Wallet.StartupTime())},
+		{"Network", netType},
+		{"Best block", fmt.Sprintf("%d", l.WL.Info.BestBlockHeight)},
+		{"Best block timestamp", time.Unix(l.WL.Info.BestBlockTime, 0).Format("2006-01-02 03:04:05 -0700")},
+		{"Best block age", l.WL.Info.LastSyncTime},
+		{"Wallet data directory", l.WL.WalletDirectory()},
+		{"Wallet data", l.WL.DataSize()},
+		{"Transactions", fmt.Sprintf("%d", l.WL.Transactions.Total)},
+		{"Wallets", fmt.Sprintf("%d", len(l.WL.Info.Wallets))},
+	}
+}
+
+func uptime(t time.Time) string {
+	v := int(time.Since(t).Seconds())
+	h := v / 3600
+	m := (v - h*3600) / 60
+	s := v - h*3600 - m*60
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
 }
+
+func (pg *StatPage) Handle() {}
+
 func (pg *StatPage) OnClose() {}
@@ -0,0 +1,253 @@
+package tickets
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// unknownVSP is the VSP of tickets that were not bought through the app.
+const unknownVSP = "unknown"
+
+var stakingCSVHeader = []string{
+	"wallet", "ticket_hash", "status", "purchase_time", "ticket_price", "tx_fee", "vsp", "vsp_fee_estimate",
+	"spender_hash", "spend_time", "days_to_vote", "reward", "return_percent",
+}
+
+// ticketRecord is the staking record of a ticket. Amounts are in atoms and
+// the VSP fee is estimated from the fee percentage of the VSP when the
+// ticket was bought.
+type ticketRecord struct {
+	wallet     string
+	hash       string
+	status     string
+	purchased  time.Time
+	price      int64
+	fees       int64
+	vspHost    string
+	vspFee     int64
+	spender    string
+	spent      time.Time
+	daysToVote int32
+	reward     int64
+	voted      bool
+	missed     bool
+	expired    bool
+}
+
+// isSpent returns true if the ticket has voted or been revoked, which is when
+// its return is realized.
+func (r ticketRecord) isSpent() bool {
+	return r.spender != ""
+}
+
+// realizedReturn returns the reward less the transaction and VSP fees as a
+// percentage of the ticket price.
+func (r ticketRecord) realizedReturn() float64 {
+	if r.price == 0 {
+		return 0
+	}
+	return float64(r.reward-r.fees-r.vspFee) / float64(r.price) * 100
+}
+
+// vspRecord is the staking record of the tickets bought through a VSP.
+type vspRecord struct {
+	host    string
+	tickets int
+	voted   int
+	missed  int
+	expired int
+	fees    int64
+}
+
+// missedRate returns the percentage of the spent or expired tickets that
+// missed their vote or expired.
+func (v vspRecord) missedRate() float64 {
+	return v.rate(v.missed)
+}
+
+func (v vspRecord) expiredRate() float64 {
+	return v.rate(v.expired)
+}
+
+func (v vspRecord) rate(count int) float64 {
+	total := v.voted + v.missed + v.expired
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total) * 100
+}
+
+// monthlyReward is the vote reward of the tickets that voted in a month.
+type monthlyReward struct {
+	month  time.Time
+	reward int64
+}
+
+// stakingAnalytics is the staking record of all tickets: the reward history,
+// averages over the voted tickets and the record of each VSP.
+type stakingAnalytics struct {
+	tickets           []ticketRecord
+	rewards           []monthlyReward
+	totalRewards      int64
+	averageDaysToVote float64
+	averageReturn     float64
+	voted             int
+	missed            int
+	expired           int
+	vsps              []vspRecord
+}
+
+// newStakingAnalytics builds the staking record of items. Tickets are
+// matched to the VSP they were bought through with purchases.
+func newStakingAnalytics(mw *dcrlibwallet.MultiWallet, items []*transactionItem, purchases []wallet.TicketPurchase) stakingAnalytics {
+	var analytics stakingAnalytics
+	rewards := make(map[time.Time]int64)
+	vsps := make(map[string]*vspRecord)
+	maxTicketAge := mw.TicketMaturity() + mw.TicketExpiry()
+
+	var daysToVote, returns float64
+	var realized int
+	for _, item := range items {
+		tx := item.transaction
+		record := ticketRecord{
+			hash:      tx.Hash,
+			status:    item.status.Title,
+			purchased: time.Unix(tx.Timestamp, 0),
+			price:     tx.Amount,
+			fees:      tx.Fee,
+			vspHost:   unknownVSP,
+			expired:   item.status.TicketStatus == dcrlibwallet.TicketStatusExpired,
+		}
+		if w := mw.WalletWithID(tx.WalletID); w != nil {
+			record.wallet = w.Name
+		}
+
+		for _, purchase := range purchases {
+			if purchase.Matches(tx.WalletID, record.purchased) {
+				record.vspHost = purchase.VSPHost
+				record.vspFee = int64(float64(tx.Amount) * purchase.FeePercentage / 100)
+				break
+			}
+		}
+
+		if spender := item.ticketSpender; spender != nil {
+			record.spender = spender.Hash
+			record.spent = time.Unix(spender.Timestamp, 0)
+			record.daysToVote = spender.DaysToVoteOrRevoke
+			record.fees += spender.Fee
+
+			if spender.Type == dcrlibwallet.TxTypeVote {
+				record.voted = true
+				record.reward = spender.VoteReward
+
+				month := time.Date(record.spent.Year(), record.spent.Month(), 1, 0, 0, 0, 0, time.Local)
+				rewards[month] += spender.VoteReward
+				analytics.totalRewards += spender.VoteReward
+				daysToVote += float64(spender.DaysToVoteOrRevoke)
+			} else if spender.BlockHeight-tx.BlockHeight > maxTicketAge {
+				record.expired = true
+			} else {
+				record.missed = true
+			}
+			returns += record.realizedReturn()
+			realized++
+		}
+
+		vsp, ok := vsps[record.vspHost]
+		if !ok {
+			vsp = &vspRecord{host: record.vspHost}
+			vsps[record.vspHost] = vsp
+		}
+		vsp.tickets++
+		vsp.fees += record.vspFee
+		switch {
+		case record.voted:
+			vsp.voted++
+			analytics.voted++
+		case record.missed:
+			vsp.missed++
+			analytics.missed++
+		case record.expired:
+			vsp.expired++
+			analytics.expired++
+		}
+
+		analytics.tickets = append(analytics.tickets, record)
+	}
+
+	if analytics.voted > 0 {
+		analytics.averageDaysToVote = daysToVote / float64(analytics.voted)
+	}
+	// tickets that expired without being revoked have no return yet
+	if realized > 0 {
+		analytics.averageReturn = returns / float64(realized)
+	}
+
+	for month, reward := range rewards {
+		analytics.rewards = append(analytics.rewards, monthlyReward{month: month, reward: reward})
+	}
+	sort.Slice(analytics.rewards, func(i, j int) bool {
+		return analytics.rewards[i].month.Before(analytics.rewards[j].month)
+	})
+
+	for _, vsp := range vsps {
+		analytics.vsps = append(analytics.vsps, *vsp)
+	}
+	sort.Slice(analytics.vsps, func(i, j int) bool {
+		return analytics.vsps[i].tickets > analytics.vsps[j].tickets
+	})
+
+	return analytics
+}
+
+// writeStakingCSV writes the staking record of each ticket to w. Amounts are
+// in DCR.
+func writeStakingCSV(w io.Writer, tickets []ticketRecord) error {
+	formatAmount := func(atoms int64) string {
+		return strconv.FormatFloat(dcrutil.Amount(atoms).ToCoin(), 'f', -1, 64)
+	}
+
+	cw := csv.NewWriter(w)
+	err := cw.Write(stakingCSVHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tickets {
+		var spendTime, daysToVote, returnPercent string
+		if t.isSpent() {
+			spendTime = t.spent.UTC().Format(time.RFC3339)
+			daysToVote = strconv.Itoa(int(t.daysToVote))
+			returnPercent = strconv.FormatFloat(t.realizedReturn(), 'f', 4, 64)
+		}
+
+		err = cw.Write([]string{
+			t.wallet,
+			t.hash,
+			t.status,
+			t.purchased.UTC().Format(time.RFC3339),
+			formatAmount(t.price),
+			formatAmount(t.fees),
+			t.vspHost,
+			formatAmount(t.vspFee),
+			t.spender,
+			spendTime,
+			daysToVote,
+			formatAmount(t.reward),
+			returnPercent,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
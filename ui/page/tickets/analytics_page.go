@@ -0,0 +1,274 @@
+package tickets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gioui.org/layout"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+const analyticsPageID = "StakingAnalytics"
+
+// maxAnalyticsTickets is the number of spent tickets listed on the analytics
+// page. All tickets are included in the CSV export.
+const maxAnalyticsTickets = 50
+
+// analyticsPage shows the staking rewards over time, the return of the
+// spent tickets and the record of each VSP.
+type analyticsPage struct {
+	*load.Load
+
+	analytics   stakingAnalytics
+	isLoading   bool
+	isExporting bool
+
+	container  layout.List
+	backButton decredmaterial.IconButton
+	exportCSV  *decredmaterial.Clickable
+}
+
+func newAnalyticsPage(l *load.Load) *analyticsPage {
+	pg := &analyticsPage{
+		Load:      l,
+		container: layout.List{Axis: layout.Vertical},
+		exportCSV: l.Theme.NewClickable(true),
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+func (pg *analyticsPage) ID() string {
+	return analyticsPageID
+}
+
+func (pg *analyticsPage) OnResume() {
+	pg.loadAnalytics()
+}
+
+func (pg *analyticsPage) loadAnalytics() {
+	pg.isLoading = true
+	go func() {
+		defer func() {
+			pg.isLoading = false
+			pg.RefreshWindow()
+		}()
+
+		mw := pg.WL.MultiWallet
+		txs, err := mw.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterTickets, false)
+		if err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+
+		items, err := ticketsToTransactionItems(pg.Load, txs, false, func(int32) bool { return false })
+		if err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+
+		pg.analytics = newStakingAnalytics(mw, items, pg.WL.Wallet.TicketPurchases())
+	}()
+}
+
+func (pg *analyticsPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      "Staking analytics",
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.PopFragment()
+			},
+			Body: func(gtx C) D {
+				if pg.isLoading {
+					return pg.Theme.Body1("Loading tickets...").Layout(gtx)
+				}
+
+				sections := []layout.Widget{
+					pg.summarySection,
+					pg.rewardsSection,
+					pg.vspSection,
+					pg.ticketsSection,
+				}
+				return pg.container.Layout(gtx, len(sections), func(gtx C, i int) D {
+					return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+						return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+							gtx.Constraints.Min.X = gtx.Constraints.Max.X
+							return layout.UniformInset(values.MarginPadding16).Layout(gtx, sections[i])
+						})
+					})
+				})
+			},
+			ExtraItem: pg.exportCSV,
+			Extra: func(gtx C) D {
+				txt := pg.Theme.Body1("Export CSV")
+				txt.Color = pg.Theme.Color.Primary
+				return layout.UniformInset(values.MarginPadding4).Layout(gtx, txt.Layout)
+			},
+			HandleExtra: func() {
+				if !pg.isExporting && !pg.isLoading {
+					pg.isExporting = true
+					go pg.export()
+				}
+			},
+		}
+		return page.Layout(gtx)
+	}
+
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *analyticsPage) sectionTitle(title string) layout.Widget {
+	return func(gtx C) D {
+		txt := pg.Theme.Label(values.TextSize14, title)
+		txt.Color = pg.Theme.Color.Gray2
+		return layout.Inset{Bottom: values.MarginPadding14}.Layout(gtx, txt.Layout)
+	}
+}
+
+func (pg *analyticsPage) row(title, value string) layout.Widget {
+	return func(gtx C) D {
+		l := pg.Theme.Body2(title)
+		r := pg.Theme.Body2(value)
+		r.Color = pg.Theme.Color.Gray
+		return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+			return components.EndToEndRow(gtx, l.Layout, r.Layout)
+		})
+	}
+}
+
+func (pg *analyticsPage) layoutWidgets(gtx C, widgets []layout.Widget) D {
+	children := make([]layout.FlexChild, len(widgets))
+	for i, w := range widgets {
+		children[i] = layout.Rigid(w)
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+func (pg *analyticsPage) summarySection(gtx C) D {
+	a := pg.analytics
+	return pg.layoutWidgets(gtx, []layout.Widget{
+		pg.sectionTitle("Summary"),
+		pg.row("Total rewards", dcrutil.Amount(a.totalRewards).String()),
+		pg.row("Tickets", fmt.Sprintf("%d", len(a.tickets))),
+		pg.row("Voted, missed, expired", fmt.Sprintf("%d, %d, %d", a.voted, a.missed, a.expired)),
+		pg.row("Average time to vote", fmt.Sprintf("%.1f days", a.averageDaysToVote)),
+		pg.row("Average return per ticket", fmt.Sprintf("%.2f%%", a.averageReturn)),
+	})
+}
+
+func (pg *analyticsPage) rewardsSection(gtx C) D {
+	rewards := pg.analytics.rewards
+	widgets := []layout.Widget{pg.sectionTitle("Vote rewards by month")}
+	if len(rewards) == 0 {
+		widgets = append(widgets, pg.Theme.Body2("No votes yet").Layout)
+		return pg.layoutWidgets(gtx, widgets)
+	}
+
+	data := make([]float32, len(rewards))
+	for i, reward := range rewards {
+		data[i] = float32(dcrutil.Amount(reward.reward).ToCoin())
+	}
+
+	first, last := rewards[0], rewards[len(rewards)-1]
+	widgets = append(widgets,
+		pg.Theme.BarChart(data).Layout,
+		func(gtx C) D {
+			l := pg.Theme.Caption(first.month.Format("Jan 2006"))
+			l.Color = pg.Theme.Color.Gray
+			r := pg.Theme.Caption(last.month.Format("Jan 2006"))
+			r.Color = pg.Theme.Color.Gray
+			return layout.Inset{Top: values.MarginPadding4, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return components.EndToEndRow(gtx, l.Layout, r.Layout)
+			})
+		},
+	)
+	for i := len(rewards) - 1; i >= 0; i-- {
+		widgets = append(widgets, pg.row(rewards[i].month.Format("January 2006"), dcrutil.Amount(rewards[i].reward).String()))
+	}
+
+	return pg.layoutWidgets(gtx, widgets)
+}
+
+func (pg *analyticsPage) vspSection(gtx C) D {
+	widgets := []layout.Widget{pg.sectionTitle("VSPs")}
+	for _, vsp := range pg.analytics.vsps {
+		value := fmt.Sprintf("%d tickets, %s fees, %.1f%% missed, %.1f%% expired",
+			vsp.tickets, dcrutil.Amount(vsp.fees), vsp.missedRate(), vsp.expiredRate())
+		widgets = append(widgets, pg.row(vsp.host, value))
+	}
+
+	txt := pg.Theme.Caption("Fees are estimated from the fee of the VSP when the tickets were bought. " +
+		"Tickets bought outside this app have an unknown VSP.")
+	txt.Color = pg.Theme.Color.Gray
+	widgets = append(widgets, txt.Layout)
+
+	return pg.layoutWidgets(gtx, widgets)
+}
+
+func (pg *analyticsPage) ticketsSection(gtx C) D {
+	widgets := []layout.Widget{pg.sectionTitle("Return per ticket")}
+	tickets := pg.analytics.tickets
+	for i, count := len(tickets)-1, 0; i >= 0 && count < maxAnalyticsTickets; i-- {
+		t := tickets[i]
+		if !t.isSpent() {
+			continue
+		}
+		count++
+
+		title := fmt.Sprintf("%s, %s", t.spent.Format("2006-01-02"), t.status)
+		value := fmt.Sprintf("%s reward, %.2f%%", dcrutil.Amount(t.reward), t.realizedReturn())
+		widgets = append(widgets, pg.row(title, value))
+	}
+
+	if len(widgets) == 1 {
+		widgets = append(widgets, pg.Theme.Body2("No tickets have voted or been revoked yet").Layout)
+	}
+	return pg.layoutWidgets(gtx, widgets)
+}
+
+func (pg *analyticsPage) Handle() {}
+
+// export writes the staking record of every ticket to a CSV file in the
+// exports directory.
+func (pg *analyticsPage) export() {
+	defer func() {
+		pg.isExporting = false
+	}()
+
+	dir := filepath.Join(pg.WL.Wallet.Root, "exports")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	path := filepath.Join(dir, fmt.Sprintf("staking-%s.csv", time.Now().Format("20060102-150405")))
+
+	f, err := os.Create(path)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	err = writeStakingCSV(f, pg.analytics.tickets)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	pg.Toast.Notify(fmt.Sprintf("Staking record exported to %s", path))
+}
+
+func (pg *analyticsPage) OnClose() {}
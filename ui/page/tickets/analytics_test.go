@@ -0,0 +1,204 @@
+package tickets
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const dcr = 100000000 // atoms
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 12, 0, 0, 0, time.UTC)
+}
+
+// testTicket returns a 10 DCR ticket bought by walletID at purchased, with a
+// fee of 0.01 DCR.
+func testTicket(walletID int, hash string, purchased time.Time, status string) *transactionItem {
+	return &transactionItem{
+		transaction: &dcrlibwallet.Transaction{
+			WalletID:    walletID,
+			Hash:        hash,
+			Timestamp:   purchased.Unix(),
+			Amount:      10 * dcr,
+			Fee:         dcr / 100,
+			BlockHeight: 1000,
+		},
+		status: &components.TxStatus{Title: status, TicketStatus: status},
+	}
+}
+
+// spend sets the spender of item, with a fee of 0.01 DCR, mined blocks after
+// the ticket.
+func spend(item *transactionItem, txType string, spent time.Time, blocks, days int32, reward int64) *transactionItem {
+	item.ticketSpender = &dcrlibwallet.Transaction{
+		Hash:               item.transaction.Hash + "-spender",
+		Type:               txType,
+		Timestamp:          spent.Unix(),
+		Fee:                dcr / 100,
+		BlockHeight:        item.transaction.BlockHeight + blocks,
+		DaysToVoteOrRevoke: days,
+		VoteReward:         reward,
+	}
+	item.status.TicketStatus = dcrlibwallet.TicketStatusVotedOrRevoked
+	return item
+}
+
+func closeEnough(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestNewStakingAnalytics(t *testing.T) {
+	mw, err := dcrlibwallet.NewMultiWallet(t.TempDir(), "bdb", "testnet3", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mw.Shutdown()
+
+	expiredAt := 2 * (mw.TicketMaturity() + mw.TicketExpiry())
+	items := []*transactionItem{
+		spend(testTicket(1, "vsp-voted", day(2021, 5, 20), "voted"), dcrlibwallet.TxTypeVote, day(2021, 6, 15), 500, 12, dcr/5),
+		spend(testTicket(1, "missed", day(2021, 1, 10), "revoked"), dcrlibwallet.TxTypeRevocation, day(2021, 2, 1), 100, 22, 0),
+		testTicket(2, "expired", day(2020, 1, 10), dcrlibwallet.TicketStatusExpired),
+		spend(testTicket(2, "revoked-expired", day(2020, 1, 10), "revoked"), dcrlibwallet.TxTypeRevocation, day(2020, 6, 1), expiredAt, 140, 0),
+		testTicket(2, "live", day(2021, 6, 1), dcrlibwallet.TicketStatusLive),
+		spend(testTicket(2, "voted", day(2021, 2, 20), "voted"), dcrlibwallet.TxTypeVote, day(2021, 3, 15), 1000, 20, dcr/5),
+	}
+	purchases := []wallet.TicketPurchase{
+		{WalletID: 1, VSPHost: "vsp.one", FeePercentage: 0.5, Count: 1, Start: day(2021, 5, 20), End: day(2021, 5, 20)},
+		// a purchase by another wallet at the same time
+		{WalletID: 2, VSPHost: "vsp.two", FeePercentage: 1, Count: 1, Start: day(2021, 5, 20), End: day(2021, 5, 20)},
+	}
+
+	a := newStakingAnalytics(mw, items, purchases)
+
+	if a.voted != 2 || a.missed != 1 || a.expired != 2 {
+		t.Errorf("got %d voted, %d missed, %d expired, want 2, 1, 2", a.voted, a.missed, a.expired)
+	}
+	if len(a.tickets) != len(items) {
+		t.Fatalf("got %d ticket records, want %d", len(a.tickets), len(items))
+	}
+
+	vspVoted := a.tickets[0]
+	want := ticketRecord{
+		hash:       "vsp-voted",
+		status:     "voted",
+		purchased:  time.Unix(day(2021, 5, 20).Unix(), 0),
+		price:      10 * dcr,
+		fees:       dcr / 50,
+		vspHost:    "vsp.one",
+		vspFee:     dcr / 20,
+		spender:    "vsp-voted-spender",
+		spent:      time.Unix(day(2021, 6, 15).Unix(), 0),
+		daysToVote: 12,
+		reward:     dcr / 5,
+		voted:      true,
+	}
+	if !reflect.DeepEqual(vspVoted, want) {
+		t.Errorf("got %+v, want %+v", vspVoted, want)
+	}
+	// 0.2 DCR reward less 0.02 DCR fees and the 0.05 DCR VSP fee
+	if got := vspVoted.realizedReturn(); !closeEnough(got, 1.3) {
+		t.Errorf("got a return of %v%%, want 1.3%%", got)
+	}
+
+	if !a.tickets[2].expired || a.tickets[2].isSpent() {
+		t.Errorf("got %+v, want an unspent expired ticket", a.tickets[2])
+	}
+	if !a.tickets[3].expired || a.tickets[3].missed {
+		t.Errorf("got %+v, want a ticket revoked after expiring", a.tickets[3])
+	}
+
+	if a.totalRewards != 2*dcr/5 {
+		t.Errorf("got %d atoms of rewards, want %d", a.totalRewards, 2*dcr/5)
+	}
+	if !closeEnough(a.averageDaysToVote, 16) {
+		t.Errorf("got %v average days to vote, want 16", a.averageDaysToVote)
+	}
+	// only the four spent tickets have a return: 1.3%, -0.2%, -0.2% and 1.8%
+	if !closeEnough(a.averageReturn, 0.675) {
+		t.Errorf("got an average return of %v%%, want 0.675%%", a.averageReturn)
+	}
+
+	if len(a.rewards) != 2 {
+		t.Fatalf("got %d months of rewards, want 2", len(a.rewards))
+	}
+	for i, month := range []time.Month{time.March, time.June} {
+		if got := a.rewards[i]; got.month.Month() != month || got.month.Day() != 1 || got.reward != dcr/5 {
+			t.Errorf("got %v rewards in %v, want %d in %v", got.reward, got.month, dcr/5, month)
+		}
+	}
+
+	wantVSPs := []vspRecord{
+		{host: unknownVSP, tickets: 5, voted: 1, missed: 1, expired: 2},
+		{host: "vsp.one", tickets: 1, voted: 1, fees: dcr / 20},
+	}
+	if !reflect.DeepEqual(a.vsps, wantVSPs) {
+		t.Fatalf("got %+v, want %+v", a.vsps, wantVSPs)
+	}
+	if got := a.vsps[0].missedRate(); !closeEnough(got, 25) {
+		t.Errorf("got a missed rate of %v%%, want 25%%", got)
+	}
+	if got := a.vsps[0].expiredRate(); !closeEnough(got, 50) {
+		t.Errorf("got an expired rate of %v%%, want 50%%", got)
+	}
+
+	empty := newStakingAnalytics(mw, nil, nil)
+	if empty.averageReturn != 0 || empty.averageDaysToVote != 0 || len(empty.vsps) != 0 {
+		t.Errorf("got %+v for no tickets", empty)
+	}
+}
+
+func TestWriteStakingCSV(t *testing.T) {
+	tickets := []ticketRecord{
+		{
+			wallet:     "default",
+			hash:       "aa",
+			status:     "voted",
+			purchased:  time.Date(2021, 5, 20, 14, 0, 0, 0, time.FixedZone("WAT", 3600)),
+			price:      10 * dcr,
+			fees:       dcr / 50,
+			vspHost:    "vsp.one",
+			vspFee:     dcr / 20,
+			spender:    "bb",
+			spent:      day(2021, 6, 15),
+			daysToVote: 26,
+			reward:     dcr / 5,
+			voted:      true,
+		},
+		{
+			wallet:    "savings",
+			hash:      "cc",
+			status:    "live",
+			purchased: day(2021, 6, 1),
+			price:     10 * dcr,
+			fees:      dcr / 100,
+			vspHost:   unknownVSP,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeStakingCSV(&buf, tickets); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		stakingCSVHeader,
+		{"default", "aa", "voted", "2021-05-20T13:00:00Z", "10", "0.02", "vsp.one", "0.05", "bb", "2021-06-15T12:00:00Z", "26", "0.2", "1.3000"},
+		{"savings", "cc", "live", "2021-06-01T12:00:00Z", "10", "0.01", unknownVSP, "0", "", "", "", "0", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("got\n%q\nwant\n%q", rows, want)
+	}
+}
@@ -0,0 +1,265 @@
+package tickets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v3"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const autoBuyerModalID = "auto_ticket_buyer_modal"
+
+// autoBuyerModal sets the policy of the automatic ticket buyer and starts it
+// with the spending passphrase.
+type autoBuyerModal struct {
+	*load.Load
+
+	isStarting bool
+	started    func()
+	canceled   func()
+
+	modal             decredmaterial.Modal
+	balanceToMaintain decredmaterial.Editor
+	maxPrice          decredmaterial.Editor
+	maxPerWindow      decredmaterial.Editor
+	spendingPassword  decredmaterial.Editor
+	cancel            decredmaterial.Button
+	start             decredmaterial.Button
+	accountSelector   *components.AccountSelector
+	vspSelector       *vspSelector
+}
+
+func newAutoBuyerModal(l *load.Load) *autoBuyerModal {
+	m := &autoBuyerModal{
+		Load: l,
+
+		modal:             *l.Theme.ModalFloatTitle(),
+		balanceToMaintain: l.Theme.Editor(new(widget.Editor), "Balance to maintain (DCR)"),
+		maxPrice:          l.Theme.Editor(new(widget.Editor), "Maximum ticket price (DCR), empty for any"),
+		maxPerWindow:      l.Theme.Editor(new(widget.Editor), "Tickets per price window, empty for no limit"),
+		spendingPassword:  l.Theme.EditorPassword(new(widget.Editor), "Spending password"),
+		cancel:            l.Theme.OutlineButton("Cancel"),
+		start:             l.Theme.Button("Start"),
+	}
+
+	m.balanceToMaintain.Editor.SingleLine = true
+	m.maxPrice.Editor.SingleLine = true
+	m.maxPerWindow.Editor.SingleLine = true
+	m.spendingPassword.Editor.SingleLine = true
+	m.spendingPassword.Editor.Submit = true
+
+	return m
+}
+
+// Started sets the function called once the buyer has started.
+func (m *autoBuyerModal) Started(started func()) *autoBuyerModal {
+	m.started = started
+	return m
+}
+
+// Canceled sets the function called if the modal is dismissed without
+// starting the buyer.
+func (m *autoBuyerModal) Canceled(canceled func()) *autoBuyerModal {
+	m.canceled = canceled
+	return m
+}
+
+func (m *autoBuyerModal) ModalID() string {
+	return autoBuyerModalID
+}
+
+func (m *autoBuyerModal) Show() {
+	m.ShowModal(m)
+}
+
+func (m *autoBuyerModal) Dismiss() {
+	m.DismissModal(m)
+}
+
+func (m *autoBuyerModal) OnResume() {
+	config := m.WL.Wallet.TicketBuyerConfig()
+
+	m.accountSelector = newTicketAccountSelector(m.Load, "Purchasing account")
+	if config.VSPHost == "" || m.accountSelector.SelectAccount(config.WalletID, config.Account) != nil {
+		err := m.accountSelector.SelectFirstWalletValidAccount()
+		if err != nil {
+			m.Toast.NotifyError(err.Error())
+		}
+	}
+
+	m.vspSelector = newVSPSelector(m.Load).title("Select a vsp")
+	switch {
+	case config.VSPHost != "":
+		m.vspSelector.selectVSP(config.VSPHost)
+	case m.WL.GetRememberVSP() != "":
+		m.vspSelector.selectVSP(m.WL.GetRememberVSP())
+	}
+
+	if config.BalanceToMaintain > 0 {
+		m.balanceToMaintain.Editor.SetText(strconv.FormatFloat(dcrutil.Amount(config.BalanceToMaintain).ToCoin(), 'f', -1, 64))
+	}
+	if config.MaxPrice > 0 {
+		m.maxPrice.Editor.SetText(strconv.FormatFloat(dcrutil.Amount(config.MaxPrice).ToCoin(), 'f', -1, 64))
+	}
+	if config.MaxPerWindow > 0 {
+		m.maxPerWindow.Editor.SetText(strconv.Itoa(int(config.MaxPerWindow)))
+	}
+}
+
+func (m *autoBuyerModal) OnDismiss() {}
+
+// config returns the policy entered in the modal.
+func (m *autoBuyerModal) config() (wallet.TicketBuyerConfig, error) {
+	var config wallet.TicketBuyerConfig
+
+	account := m.accountSelector.SelectedAccount()
+	if account == nil {
+		return config, fmt.Errorf("no account selected")
+	}
+	config.WalletID = account.WalletID
+	config.Account = account.Number
+
+	vsp := m.vspSelector.SelectedVSP()
+	if vsp == nil {
+		return config, fmt.Errorf("no VSP selected")
+	}
+	config.VSPHost = vsp.Host
+	config.VSPFeePercentage = vsp.Info.FeePercentage
+
+	parseAmount := func(editor decredmaterial.Editor) (int64, error) {
+		text := strings.TrimSpace(editor.Editor.Text())
+		if text == "" {
+			return 0, nil
+		}
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q", text)
+		}
+		amount, err := dcrutil.NewAmount(value)
+		return int64(amount), err
+	}
+
+	var err error
+	if config.BalanceToMaintain, err = parseAmount(m.balanceToMaintain); err != nil {
+		return config, err
+	}
+	if config.MaxPrice, err = parseAmount(m.maxPrice); err != nil {
+		return config, err
+	}
+
+	if text := strings.TrimSpace(m.maxPerWindow.Editor.Text()); text != "" {
+		count, err := strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			return config, fmt.Errorf("invalid ticket count %q", text)
+		}
+		config.MaxPerWindow = int32(count)
+	}
+
+	return config, config.Validate()
+}
+
+func (m *autoBuyerModal) Layout(gtx layout.Context) layout.Dimensions {
+	editor := func(e decredmaterial.Editor) layout.Widget {
+		return func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, e.Layout)
+		}
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			return m.Theme.Label(values.TextSize20, "Automatic ticket buyer").Layout(gtx)
+		},
+		func(gtx C) D {
+			txt := m.Theme.Body2("Tickets are bought whenever a block is found and the policy allows it. " +
+				"The spending password is kept until the buyer is stopped or the app is closed.")
+			txt.Color = m.Theme.Color.Gray
+			return txt.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(m.accountSelector.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, m.vspSelector.Layout)
+				}),
+			)
+		},
+		editor(m.balanceToMaintain),
+		editor(m.maxPrice),
+		editor(m.maxPerWindow),
+		editor(m.spendingPassword),
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, m.cancel.Layout)
+					}),
+					layout.Rigid(m.start.Layout),
+				)
+			})
+		},
+	}
+
+	return m.modal.Layout(gtx, w, 850)
+}
+
+func (m *autoBuyerModal) Handle() {
+	m.start.SetEnabled(!m.isStarting && m.spendingPassword.Editor.Text() != "")
+
+	isSubmit, _ := decredmaterial.HandleEditorEvents(m.spendingPassword.Editor)
+	if (m.start.Clicked() || isSubmit) && !m.isStarting {
+		m.startBuyer()
+	}
+
+	if m.cancel.Clicked() || m.modal.BackdropClicked(true) {
+		if !m.isStarting {
+			m.Dismiss()
+			if m.canceled != nil {
+				m.canceled()
+			}
+		}
+	}
+}
+
+func (m *autoBuyerModal) startBuyer() {
+	config, err := m.config()
+	if err != nil {
+		m.Toast.NotifyError(err.Error())
+		return
+	}
+
+	password := m.spendingPassword.Editor.Text()
+	if password == "" {
+		m.spendingPassword.SetError("Enter the spending password")
+		return
+	}
+	m.spendingPassword.ClearError()
+
+	m.isStarting = true
+	go func() {
+		defer func() {
+			m.isStarting = false
+		}()
+
+		err := m.WL.Wallet.StartTicketBuyer(config, []byte(password))
+		if err != nil {
+			m.spendingPassword.SetError(err.Error())
+			m.RefreshWindow()
+			return
+		}
+
+		m.Dismiss()
+		m.Toast.Notify("Automatic ticket buyer started")
+		if m.started != nil {
+			m.started()
+		}
+	}()
+}
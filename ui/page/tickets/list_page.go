@@ -1,10 +1,10 @@
 package tickets
 
 import (
-	"context"
 	"image/color"
 
 	"gioui.org/layout"
+	"gioui.org/op"
 	"gioui.org/text"
 
 	"github.com/decred/dcrd/dcrutil"
@@ -33,8 +33,7 @@ const (
 type ListPage struct {
 	*load.Load
 
-	ctx       context.Context // page context
-	ctxCancel context.CancelFunc
+	events *wallet.Subscription
 
 	tickets     []*transactionItem
 	ticketsList layout.List
@@ -78,40 +77,31 @@ func (pg *ListPage) ID() string {
 }
 
 func (pg *ListPage) OnResume() {
-	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
 	pg.wallets = pg.WL.SortedWalletList()
 	components.CreateOrUpdateWalletDropDown(pg.Load, &pg.walletDropDown, pg.wallets)
-	pg.listenForTxNotifications()
+	pg.subscribe()
 	pg.fetchTickets()
 }
 
-func (pg *ListPage) listenForTxNotifications() {
-	go func() {
-		for {
-			var notification interface{}
-
-			select {
-			case notification = <-pg.Receiver.NotificationsUpdate:
-			case <-pg.ctx.Done():
-				return
-			}
-
-			switch n := notification.(type) {
-			case wallet.NewBlock:
-				selectedWallet := pg.wallets[pg.walletDropDown.SelectedIndex()]
-				if selectedWallet.ID == n.WalletID {
-					pg.fetchTickets()
-					pg.RefreshWindow()
-				}
-			case wallet.NewTransaction:
-				selectedWallet := pg.wallets[pg.walletDropDown.SelectedIndex()]
-				if selectedWallet.ID == n.Transaction.WalletID {
-					pg.fetchTickets()
-					pg.RefreshWindow()
-				}
-			}
+// subscribe reloads the tickets when the selected wallet receives a block
+// or a transaction.
+func (pg *ListPage) subscribe() {
+	reload := func(walletID int) {
+		selectedWallet := pg.wallets[pg.walletDropDown.SelectedIndex()]
+		if selectedWallet.ID == walletID {
+			pg.fetchTickets()
+			pg.RefreshWindow()
 		}
-	}()
+	}
+
+	pg.events = pg.WL.Wallet.Events().Subscribe(wallet.EventHandlers{
+		OnBlock: func(block wallet.NewBlock) {
+			reload(block.WalletID)
+		},
+		OnTransaction: func(tx wallet.NewTransaction) {
+			reload(tx.Transaction.WalletID)
+		},
+	})
 }
 
 func (pg *ListPage) fetchTickets() {
@@ -267,7 +257,9 @@ func (pg *ListPage) ticketListLayout(gtx layout.Context, tickets []*transactionI
 	return pg.ticketsList.Layout(gtx, len(tickets), func(gtx C, index int) D {
 		var ticket = tickets[index]
 
-		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		// the row is recorded so the clickable can lay it out
+		m := op.Record(gtx.Ops)
+		dims := layout.Flex{Alignment: layout.Middle}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
 				return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
 					return layout.Stack{Alignment: layout.S}.Layout(gtx,
@@ -345,6 +337,12 @@ func (pg *ListPage) ticketListLayout(gtx layout.Context, tickets []*transactionI
 				)
 			}),
 		)
+		row := m.Stop()
+
+		return ticket.explorer.Layout(gtx, func(gtx C) D {
+			row.Add(gtx.Ops)
+			return dims
+		})
 	})
 }
 
@@ -363,7 +361,9 @@ func (pg *ListPage) ticketListGridLayout(gtx layout.Context, tickets []*transact
 					Right:  values.MarginPadding4,
 					Bottom: values.MarginPadding8,
 				}.Layout(gtx, func(gtx C) D {
-					return ticketCard(gtx, pg.Load, tickets[index], false)
+					return tickets[index].explorer.Layout(gtx, func(gtx C) D {
+						return ticketCard(gtx, pg.Load, tickets[index], false)
+					})
 				})
 			})
 		})
@@ -386,8 +386,16 @@ func (pg *ListPage) Handle() {
 	for pg.ticketTypeDropDown.Changed() {
 		pg.fetchTickets()
 	}
+
+	for _, ticket := range pg.tickets {
+		for ticket.explorer.Clicked() {
+			if explorerURL := pg.WL.Wallet.GetBlockExplorerURL(ticket.transaction.Hash); explorerURL != "" {
+				components.GoToURL(explorerURL)
+			}
+		}
+	}
 }
 
 func (pg *ListPage) OnClose() {
-	pg.ctxCancel()
+	pg.events.Unsubscribe()
 }
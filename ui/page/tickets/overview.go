@@ -20,6 +20,10 @@ type (
 
 const OverviewPageID = "Tickets"
 
+// maxAutoBuyerDecisions is the number of ticket buyer decisions shown on the
+// overview page.
+const maxAutoBuyerDecisions = 20
+
 type Page struct {
 	*load.Load
 
@@ -33,6 +37,7 @@ type Page struct {
 
 	autoPurchaseEnabled *decredmaterial.Switch
 	toTickets           decredmaterial.TextAndIconButton
+	toAnalytics         decredmaterial.TextAndIconButton
 
 	stakingOverview *dcrlibwallet.StakingOverview
 	liveTickets     []*transactionItem
@@ -48,10 +53,13 @@ func NewTicketPage(l *load.Load) *Page {
 
 		autoPurchaseEnabled: l.Theme.Switch(),
 		toTickets:           l.Theme.TextAndIconButton("See All", l.Icons.NavigationArrowForward),
+		toAnalytics:         l.Theme.TextAndIconButton("Analytics", l.Icons.NavigationArrowForward),
 	}
 
 	pg.toTickets.Color = l.Theme.Color.Primary
 	pg.toTickets.BackgroundColor = l.Theme.Color.Surface
+	pg.toAnalytics.Color = l.Theme.Color.Primary
+	pg.toAnalytics.BackgroundColor = l.Theme.Color.Surface
 
 	pg.stakingOverview = new(dcrlibwallet.StakingOverview)
 	return pg
@@ -66,8 +74,7 @@ func (pg *Page) OnResume() {
 	pg.loadPageData()
 
 	go pg.WL.GetVSPList()
-	// TODO: automatic ticket purchase functionality
-	pg.autoPurchaseEnabled.Disabled()
+	pg.autoPurchaseEnabled.SetChecked(pg.WL.Wallet.TicketBuyerRunning())
 }
 
 func (pg *Page) loadPageData() {
@@ -143,6 +150,9 @@ func (pg *Page) Layout(gtx layout.Context) layout.Dimensions {
 			func(ctx layout.Context) layout.Dimensions {
 				return pg.stakingRecordSection(gtx)
 			},
+			func(ctx layout.Context) layout.Dimensions {
+				return pg.autoBuyerSection(gtx)
+			},
 		}
 		return pg.ticketPageContainer.Layout(gtx, len(sections), func(gtx C, i int) D {
 			return sections[i](gtx)
@@ -175,32 +185,40 @@ func (pg *Page) ticketPriceSection(gtx layout.Context) layout.Dimensions {
 				return layout.Inset{
 					Bottom: values.MarginPadding11,
 				}.Layout(gtx, func(gtx C) D {
-					// leftWg := func(gtx C) D {
-					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
-						layout.Rigid(func(gtx C) D {
-							title := pg.Theme.Label(values.TextSize14, "Ticket Price")
-							title.Color = pg.Theme.Color.Gray2
-							return title.Layout(gtx)
-						}),
-						layout.Rigid(func(gtx C) D {
-							return layout.Inset{
-								Left:  values.MarginPadding8,
-								Right: values.MarginPadding4,
-							}.Layout(gtx, func(gtx C) D {
-								ic := pg.Icons.TimerIcon
-								return ic.Layout12dp(gtx)
-							})
-						}),
-						layout.Rigid(func(gtx C) D {
-							secs, _ := pg.WL.MultiWallet.NextTicketPriceRemaining()
-							txt := pg.Theme.Label(values.TextSize14, nextTicketRemaining(int(secs)))
-							txt.Color = pg.Theme.Color.Gray2
-							return txt.Layout(gtx)
-						}),
-					)
-					// }
-					//TODO: auto ticket purchase.
-					// return pg.titleRow(gtx, leftWg, pg.autoPurchaseEnabled.Layout)-
+					leftWg := func(gtx C) D {
+						return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								title := pg.Theme.Label(values.TextSize14, "Ticket Price")
+								title.Color = pg.Theme.Color.Gray2
+								return title.Layout(gtx)
+							}),
+							layout.Rigid(func(gtx C) D {
+								return layout.Inset{
+									Left:  values.MarginPadding8,
+									Right: values.MarginPadding4,
+								}.Layout(gtx, func(gtx C) D {
+									ic := pg.Icons.TimerIcon
+									return ic.Layout12dp(gtx)
+								})
+							}),
+							layout.Rigid(func(gtx C) D {
+								secs, _ := pg.WL.MultiWallet.NextTicketPriceRemaining()
+								txt := pg.Theme.Label(values.TextSize14, nextTicketRemaining(int(secs)))
+								txt.Color = pg.Theme.Color.Gray2
+								return txt.Layout(gtx)
+							}),
+						)
+					}
+					return pg.titleRow(gtx, leftWg, func(gtx C) D {
+						return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(func(gtx C) D {
+								txt := pg.Theme.Label(values.TextSize14, "Auto purchase")
+								txt.Color = pg.Theme.Color.Gray2
+								return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, txt.Layout)
+							}),
+							layout.Rigid(pg.autoPurchaseEnabled.Layout),
+						)
+					})
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
@@ -284,7 +302,7 @@ func (pg *Page) stakingRecordSection(gtx C) D {
 				}.Layout(gtx, func(gtx C) D {
 					title := pg.Theme.Label(values.TextSize14, "Staking Record")
 					title.Color = pg.Theme.Color.Gray2
-					return pg.titleRow(gtx, title.Layout, func(gtx C) D { return D{} })
+					return pg.titleRow(gtx, title.Layout, pg.toAnalytics.Layout)
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
@@ -366,7 +384,76 @@ func (pg *Page) stakingRecordIconCount(icon *decredmaterial.Image, count int, st
 	}
 }
 
+// autoBuyerSection lists the latest decisions of the automatic ticket buyer,
+// newest first.
+func (pg *Page) autoBuyerSection(gtx C) D {
+	decisions := pg.WL.Wallet.TicketBuyerHistory()
+	if len(decisions) == 0 {
+		return D{}
+	}
+	if len(decisions) > maxAutoBuyerDecisions {
+		decisions = decisions[len(decisions)-maxAutoBuyerDecisions:]
+	}
+
+	return pg.pageSections(gtx, func(gtx C) D {
+		status := "Stopped"
+		if pg.WL.Wallet.TicketBuyerRunning() {
+			status = "Running"
+		}
+
+		rows := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding14}.Layout(gtx, func(gtx C) D {
+					title := pg.Theme.Label(values.TextSize14, "Auto Purchase History")
+					title.Color = pg.Theme.Color.Gray2
+					txt := pg.Theme.Label(values.TextSize14, status)
+					txt.Color = pg.Theme.Color.Gray
+					return pg.titleRow(gtx, title.Layout, txt.Layout)
+				})
+			}),
+		}
+
+		for i := len(decisions) - 1; i >= 0; i-- {
+			decision := decisions[i]
+			rows = append(rows, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					when := pg.Theme.Label(values.TextSize12, fmt.Sprintf("%s, block %d", decision.Time.Format("2006-01-02 15:04:05"), decision.Height))
+					when.Color = pg.Theme.Color.Gray
+					reason := pg.Theme.Label(values.TextSize14, decision.Reason)
+					switch {
+					case decision.Error:
+						reason.Color = pg.Theme.Color.Danger
+					case decision.Purchased > 0:
+						reason.Color = pg.Theme.Color.Success
+					default:
+						reason.Color = pg.Theme.Color.DeepBlue
+					}
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(reason.Layout),
+						layout.Rigid(when.Layout),
+					)
+				})
+			}))
+		}
+
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+	})
+}
+
 func (pg *Page) Handle() {
+	if pg.autoPurchaseEnabled.Changed() {
+		if pg.autoPurchaseEnabled.IsChecked() {
+			newAutoBuyerModal(pg.Load).
+				Started(pg.RefreshWindow).
+				Canceled(func() {
+					pg.autoPurchaseEnabled.SetChecked(false)
+				}).Show()
+		} else {
+			pg.WL.Wallet.StopTicketBuyer()
+			pg.Toast.Notify("Automatic ticket buyer stopped")
+		}
+	}
+
 	if pg.purchaseTicket.Clicked() {
 		newTicketPurchaseModal(pg.Load).
 			TicketPurchased(func() {
@@ -378,6 +465,10 @@ func (pg *Page) Handle() {
 	if pg.toTickets.Button.Clicked() {
 		pg.ChangeFragment(newListPage(pg.Load))
 	}
+
+	if pg.toAnalytics.Button.Clicked() {
+		pg.ChangeFragment(newAnalyticsPage(pg.Load))
+	}
 }
 
 func (pg *Page) OnClose() {}
@@ -26,6 +26,9 @@ type ticketPurchaseModal struct {
 	vspIsFetched     bool
 	ticketsPurchased func()
 
+	exchangeRate     float64
+	exchangeCurrency string
+
 	modal           decredmaterial.Modal
 	tickets         decredmaterial.Editor
 	rememberVSP     decredmaterial.CheckBoxStyle
@@ -73,6 +76,22 @@ func (tp *ticketPurchaseModal) OnResume() {
 		tp.vspSelector.selectVSP(tp.WL.GetRememberVSP())
 		tp.rememberVSP.CheckBox.Value = true
 	}
+
+	tp.exchangeCurrency = tp.WL.ExchangeCurrency()
+	if tp.exchangeCurrency != "" {
+		go tp.fetchExchangeRate()
+	}
+}
+
+func (tp *ticketPurchaseModal) fetchExchangeRate() {
+	rate, err := tp.WL.Wallet.ExchangeRate(tp.exchangeCurrency)
+	if err != nil {
+		// the fiat total is not displayed without a rate
+		return
+	}
+
+	tp.exchangeRate = rate.Rate
+	tp.RefreshWindow()
 }
 
 func (tp *ticketPurchaseModal) Layout(gtx layout.Context) layout.Dimensions {
@@ -107,6 +126,17 @@ func (tp *ticketPurchaseModal) Layout(gtx layout.Context) layout.Dimensions {
 									layout.Rigid(func(gtx C) D {
 										return tp.Theme.Label(values.TextSize16, dcrutil.Amount(int64(tp.ticketPrice)*tp.ticketCount()).String()).Layout(gtx)
 									}),
+									layout.Rigid(func(gtx C) D {
+										if tp.exchangeRate <= 0 {
+											return D{}
+										}
+
+										total := dcrutil.Amount(int64(tp.ticketPrice) * tp.ticketCount()).ToCoin()
+										fiatTotal := load.FormatFiatBalance(tp.Printer, tp.exchangeCurrency, load.DCRToFiat(tp.exchangeRate, total))
+										txt := tp.Theme.Label(values.TextSize14, fiatTotal)
+										txt.Color = tp.Theme.Color.Gray
+										return txt.Layout(gtx)
+									}),
 								)
 							}),
 							layout.Flexed(.5, tp.tickets.Layout),
@@ -199,11 +229,17 @@ func (tp *ticketPurchaseModal) Dismiss() {
 }
 
 func (tp *ticketPurchaseModal) initializeAccountSelector() {
-	tp.accountSelector = components.NewAccountSelector(tp.Load).
-		Title("Purchasing account").
+	tp.accountSelector = newTicketAccountSelector(tp.Load, "Purchasing account")
+}
+
+// newTicketAccountSelector returns an account selector for the accounts
+// tickets can be bought from.
+func newTicketAccountSelector(l *load.Load, title string) *components.AccountSelector {
+	return components.NewAccountSelector(l).
+		Title(title).
 		AccountSelected(func(selectedAccount *dcrlibwallet.Account) {}).
 		AccountValidator(func(account *dcrlibwallet.Account) bool {
-			wal := tp.WL.MultiWallet.WalletWithID(account.WalletID)
+			wal := l.WL.MultiWallet.WalletWithID(account.WalletID)
 
 			// Imported and watch only wallet accounts are invalid for sending
 			accountIsValid := account.Number != dcrlibwallet.ImportedAccountNumber && !wal.IsWatchingOnlyWallet()
@@ -2,6 +2,7 @@ package tickets
 
 import (
 	"fmt"
+	"time"
 
 	"gioui.org/font/gofont"
 	"gioui.org/layout"
@@ -171,8 +172,6 @@ func (t *ticketReviewModal) purchaseTickets() {
 	go func() {
 		password := []byte(t.spendingPassword.Editor.Text())
 
-		wal := t.WL.MultiWallet.WalletWithID(t.account.WalletID)
-
 		defer func() {
 			t.isLoading = false
 		}()
@@ -183,12 +182,22 @@ func (t *ticketReviewModal) purchaseTickets() {
 			return
 		}
 
-		err = vsp.PurchaseTickets(int32(t.ticketCount), wal.GetBestBlock()+256, password)
+		start := time.Now()
+		err = vsp.PurchaseTickets(int32(t.ticketCount), wallet.TicketExpiry, password)
 		if err != nil {
 			t.Toast.NotifyError(err.Error())
 			return
 		}
 
+		t.WL.Wallet.RecordTicketPurchase(wallet.TicketPurchase{
+			WalletID:      t.account.WalletID,
+			VSPHost:       t.selectedVSP.Host,
+			FeePercentage: t.selectedVSP.Info.FeePercentage,
+			Count:         int32(t.ticketCount),
+			Start:         start,
+			End:           time.Now(),
+		})
+
 		t.ticketsPurchased()
 		t.Dismiss()
 		t.Toast.Notify(fmt.Sprintf("%v ticket(s) purchased successfully", t.ticketCount))
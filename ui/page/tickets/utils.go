@@ -31,6 +31,9 @@ type transactionItem struct {
 	purchaseTime  string
 	ticketAge     string
 
+	// explorer opens the ticket on the block explorer.
+	explorer *decredmaterial.Clickable
+
 	statusTooltip     *decredmaterial.Tooltip
 	walletNameTooltip *decredmaterial.Tooltip
 	dateTooltip       *decredmaterial.Tooltip
@@ -130,6 +133,7 @@ func ticketsToTransactionItems(l *load.Load, txs []dcrlibwallet.Transaction, new
 			showTime:      showTime,
 			purchaseTime:  time.Unix(tx.Timestamp, 0).Format("Jan 2"),
 			ticketAge:     ticketAge,
+			explorer:      l.Theme.NewClickable(true),
 
 			statusTooltip:     l.Theme.Tooltip(),
 			walletNameTooltip: l.Theme.Tooltip(),
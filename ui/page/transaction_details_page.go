@@ -17,6 +17,7 @@ import (
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const TransactionDetailsPageID = "TransactionDetails"
@@ -28,6 +29,10 @@ type transactionWdg struct {
 	time, status, wallet decredmaterial.Label
 
 	copyTextButtons []decredmaterial.Button
+	// explorerButtons open explorerURLs, the block explorer pages of the
+	// previous transaction of each input and the address of each output.
+	explorerButtons []*decredmaterial.Clickable
+	explorerURLs    []string
 }
 
 type TransactionDetailsPage struct {
@@ -39,8 +44,11 @@ type TransactionDetailsPage struct {
 	associatedTicketClickable       *decredmaterial.Clickable
 	hashClickable                   *widget.Clickable
 	destAddressClickable            *widget.Clickable
+	destAddressExplorer             *decredmaterial.Clickable
+	blockClickable                  *widget.Clickable
 	dot                             *widget.Icon
 	toDcrdata                       *decredmaterial.Clickable
+	editLabel                       decredmaterial.Button
 	outputsCollapsible              *decredmaterial.Collapsible
 	inputsCollapsible               *decredmaterial.Collapsible
 	backButton                      decredmaterial.IconButton
@@ -53,8 +61,10 @@ type TransactionDetailsPage struct {
 	ticketSpent   *dcrlibwallet.Transaction // ticket spent in a vote or revoke
 	wallet        *dcrlibwallet.Wallet
 
+	txLabel              wallet.Label
 	txSourceAccount      string
 	txDestinationAddress string
+	contactNames         map[string]string // address book names by address
 }
 
 func NewTransactionDetailsPage(l *load.Load, transaction *dcrlibwallet.Transaction) *TransactionDetailsPage {
@@ -78,7 +88,10 @@ func NewTransactionDetailsPage(l *load.Load, transaction *dcrlibwallet.Transacti
 		associatedTicketClickable: l.Theme.NewClickable(true),
 		hashClickable:             new(widget.Clickable),
 		destAddressClickable:      new(widget.Clickable),
+		destAddressExplorer:       l.Theme.NewClickable(true),
+		blockClickable:            new(widget.Clickable),
 		toDcrdata:                 l.Theme.NewClickable(true),
+		editLabel:                 l.Theme.OutlineButton("Edit"),
 
 		transaction: transaction,
 		wallet:      l.WL.MultiWallet.WalletWithID(transaction.WalletID),
@@ -86,6 +99,9 @@ func NewTransactionDetailsPage(l *load.Load, transaction *dcrlibwallet.Transacti
 
 	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(pg.Load)
 
+	pg.editLabel.TextSize = values.TextSize14
+	pg.editLabel.Inset = layout.UniformInset(values.MarginPadding0)
+
 	pg.dot = l.Icons.ImageBrightness1
 	pg.dot.Color = l.Theme.Color.Gray
 
@@ -113,6 +129,12 @@ func NewTransactionDetailsPage(l *load.Load, transaction *dcrlibwallet.Transacti
 		}
 	}
 
+	pg.contactNames = make(map[string]string)
+	contacts, _ := l.WL.Contacts()
+	for _, contact := range contacts {
+		pg.contactNames[contact.Address] = contact.Name
+	}
+
 	pg.txnWidgets = initTxnWidgets(pg.Load, pg.transaction)
 
 	return pg
@@ -123,6 +145,8 @@ func (pg *TransactionDetailsPage) ID() string {
 }
 
 func (pg *TransactionDetailsPage) OnResume() {
+	pg.txLabel = pg.WL.Wallet.Label(pg.transaction.WalletID, wallet.LabelTx, pg.transaction.Hash)
+
 	if pg.transaction.TicketSpentHash != "" {
 		pg.ticketSpent, _ = pg.wallet.GetTransactionRaw(pg.transaction.TicketSpentHash)
 	}
@@ -166,6 +190,12 @@ func (pg *TransactionDetailsPage) Layout(gtx layout.Context) layout.Dimensions {
 					func(gtx C) D {
 						return pg.theme.Separator().Layout(gtx)
 					},
+					func(gtx C) D {
+						return pg.txnLabel(gtx)
+					},
+					func(gtx C) D {
+						return pg.theme.Separator().Layout(gtx)
+					},
 					func(gtx C) D {
 						return pg.txnInputs(gtx)
 					},
@@ -467,7 +497,17 @@ func (pg *TransactionDetailsPage) txnTypeAndID(gtx layout.Context) layout.Dimens
 		layout.Rigid(func(gtx C) D {
 			if transaction.Direction == dcrlibwallet.TxDirectionSent {
 				return layout.Inset{Top: m}.Layout(gtx, func(gtx C) D {
-					return pg.txnInfoSection(gtx, values.String(values.StrTo), pg.txDestinationAddress, false, pg.destAddressClickable)
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, func(gtx C) D {
+							return pg.txnInfoSection(gtx, values.String(values.StrTo), pg.txDestinationAddress, false, pg.destAddressClickable)
+						}),
+						layout.Rigid(func(gtx C) D {
+							if pg.txDestinationAddress == "" || pg.WL.Wallet.BlockExplorerAddressURL(pg.txDestinationAddress) == "" {
+								return layout.Dimensions{}
+							}
+							return pg.explorerLink(gtx, pg.destAddressExplorer)
+						}),
+					)
 				})
 			}
 			return layout.Dimensions{}
@@ -480,7 +520,11 @@ func (pg *TransactionDetailsPage) txnTypeAndID(gtx layout.Context) layout.Dimens
 		layout.Rigid(func(gtx C) D {
 			if transaction.BlockHeight != -1 {
 				return layout.Inset{Top: m}.Layout(gtx, func(gtx C) D {
-					return pg.txnInfoSection(gtx, values.String(values.StrIncludedInBlock), fmt.Sprintf("%d", transaction.BlockHeight), false, nil)
+					var clickable *widget.Clickable
+					if pg.WL.Wallet.BlockExplorerBlockURL(transaction.BlockHeight) != "" {
+						clickable = pg.blockClickable
+					}
+					return pg.txnInfoSection(gtx, values.String(values.StrIncludedInBlock), fmt.Sprintf("%d", transaction.BlockHeight), false, clickable)
 				})
 			}
 			return layout.Dimensions{}
@@ -542,6 +586,41 @@ func (pg *TransactionDetailsPage) txnInfoSection(gtx layout.Context, label, valu
 	)
 }
 
+// txnLabel lays out the label and note the user gave the transaction.
+func (pg *TransactionDetailsPage) txnLabel(gtx layout.Context) layout.Dimensions {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return pg.pageSections(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Spacing: layout.SpaceBetween, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						t := pg.theme.Label(values.TextSize14, "Label")
+						t.Color = pg.theme.Color.Gray
+						return t.Layout(gtx)
+					}),
+					layout.Rigid(pg.editLabel.Layout),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.txLabel.IsEmpty() {
+					txt := pg.theme.Body2("No label")
+					txt.Color = pg.theme.Color.Gray3
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, txt.Layout)
+				}
+				return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.theme.Body1(pg.txLabel.Label).Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.txLabel.Note == "" {
+					return D{}
+				}
+				txt := pg.theme.Body2(pg.txLabel.Note)
+				txt.Color = pg.theme.Color.Gray
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, txt.Layout)
+			}),
+		)
+	})
+}
+
 func (pg *TransactionDetailsPage) txnInputs(gtx layout.Context) layout.Dimensions {
 	transaction := pg.transaction
 
@@ -593,6 +672,8 @@ func (pg *TransactionDetailsPage) txnIORow(gtx layout.Context, amount int64, acc
 			accountName = name
 			walletName = pg.wallet.Name
 		}
+	} else if name, ok := pg.contactNames[address]; ok {
+		accountName = name
 	}
 
 	accountName = fmt.Sprintf("(%s)", accountName)
@@ -632,7 +713,15 @@ func (pg *TransactionDetailsPage) txnIORow(gtx layout.Context, amount int64, acc
 					layout.Rigid(func(gtx C) D {
 						pg.txnWidgets.copyTextButtons[i].Text = address
 
-						return layout.W.Layout(gtx, pg.txnWidgets.copyTextButtons[i].Layout)
+						return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(pg.txnWidgets.copyTextButtons[i].Layout),
+							layout.Rigid(func(gtx C) D {
+								if pg.txnWidgets.explorerURLs[i] == "" {
+									return layout.Dimensions{}
+								}
+								return pg.explorerLink(gtx, pg.txnWidgets.explorerButtons[i])
+							}),
+						)
 					}),
 				)
 			})
@@ -640,7 +729,18 @@ func (pg *TransactionDetailsPage) txnIORow(gtx layout.Context, amount int64, acc
 	})
 }
 
+// explorerLink lays out an icon that opens a block explorer page.
+func (pg *TransactionDetailsPage) explorerLink(gtx layout.Context, clickable *decredmaterial.Clickable) layout.Dimensions {
+	return layout.Inset{Left: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+		return clickable.Layout(gtx, pg.Icons.RedirectIcon.Layout16dp)
+	})
+}
+
 func (pg *TransactionDetailsPage) viewTxn(gtx layout.Context) layout.Dimensions {
+	if pg.WL.Wallet.GetBlockExplorerURL(pg.transaction.Hash) == "" {
+		return layout.Dimensions{}
+	}
+
 	gtx.Constraints.Min.X = gtx.Constraints.Max.X
 	return pg.pageSections(gtx, func(gtx C) D {
 		return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
@@ -680,6 +780,28 @@ func (pg *TransactionDetailsPage) Handle() {
 		pg.Toast.Notify("Address copied")
 	}
 
+	for pg.destAddressExplorer.Clicked() {
+		components.GoToURL(pg.WL.Wallet.BlockExplorerAddressURL(pg.txDestinationAddress))
+	}
+
+	for i, b := range pg.txnWidgets.explorerButtons {
+		for b.Clicked() {
+			components.GoToURL(pg.txnWidgets.explorerURLs[i])
+		}
+	}
+
+	for pg.blockClickable.Clicked() {
+		components.GoToURL(pg.WL.Wallet.BlockExplorerBlockURL(pg.transaction.BlockHeight))
+	}
+
+	for pg.editLabel.Clicked() {
+		modal.NewLabelModal(pg.Load, pg.transaction.WalletID, wallet.LabelTx, pg.transaction.Hash).
+			Title("Transaction label").
+			LabelSaved(func(label wallet.Label) {
+				pg.txLabel = label
+			}).Show()
+	}
+
 	for pg.associatedTicketClickable.Clicked() {
 		if pg.ticketSpent != nil {
 			pg.ChangeFragment(NewTransactionDetailsPage(pg.Load, pg.ticketSpent))
@@ -726,5 +848,20 @@ func initTxnWidgets(l *load.Load, transaction *dcrlibwallet.Transaction) transac
 		txn.copyTextButtons[i] = btn
 	}
 
+	// inputs carry no address, so they link to the transaction they spend
+	txn.explorerButtons = make([]*decredmaterial.Clickable, x)
+	txn.explorerURLs = make([]string, x)
+	for i, input := range transaction.Inputs {
+		txn.explorerURLs[i] = l.WL.Wallet.GetBlockExplorerURL(input.PreviousTransactionHash)
+	}
+	for i, output := range transaction.Outputs {
+		if output.Address != "" {
+			txn.explorerURLs[len(transaction.Inputs)+i] = l.WL.Wallet.BlockExplorerAddressURL(output.Address)
+		}
+	}
+	for i := range txn.explorerButtons {
+		txn.explorerButtons[i] = l.Theme.NewClickable(true)
+	}
+
 	return txn
 }
@@ -1,9 +1,8 @@
 package page
 
 import (
-	"context"
-
 	"gioui.org/layout"
+	"gioui.org/unit"
 
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
@@ -17,8 +16,7 @@ const TransactionsPageID = "Transactions"
 
 type TransactionsPage struct {
 	*load.Load
-	ctx       context.Context // page context
-	ctxCancel context.CancelFunc
+	events    *wallet.Subscription
 	container layout.Flex
 	separator decredmaterial.Line
 
@@ -26,9 +24,11 @@ type TransactionsPage struct {
 	txTypeDropDown  *decredmaterial.DropDown
 	walletDropDown  *decredmaterial.DropDown
 	transactionList *decredmaterial.ClickableList
+	exportButton    decredmaterial.Button
+	searchButton    decredmaterial.Button
 
-	transactions []dcrlibwallet.Transaction
-	wallets      []*dcrlibwallet.Wallet
+	pager   *txPager
+	wallets []*dcrlibwallet.Wallet
 }
 
 func NewTransactionsPage(l *load.Load) *TransactionsPage {
@@ -37,6 +37,9 @@ func NewTransactionsPage(l *load.Load) *TransactionsPage {
 		container:       layout.Flex{Axis: layout.Vertical},
 		separator:       l.Theme.Separator(),
 		transactionList: l.Theme.NewClickableList(layout.Vertical),
+		exportButton:    l.Theme.OutlineButton(values.String(values.StrExport)),
+		searchButton:    l.Theme.OutlineButton("Search"),
+		pager:           newTxPager(l.RefreshWindow),
 	}
 
 	pg.transactionList.Radius = decredmaterial.Radius(values.MarginPadding14.V)
@@ -71,18 +74,67 @@ func (pg *TransactionsPage) ID() string {
 }
 
 func (pg *TransactionsPage) OnResume() {
-	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
-
 	pg.wallets = pg.WL.SortedWalletList()
-	components.CreateOrUpdateWalletDropDown(pg.Load, &pg.walletDropDown, pg.wallets)
-	pg.listenForTxNotifications()
+	components.CreateOrUpdateAllWalletsDropDown(pg.Load, &pg.walletDropDown, pg.wallets)
+	pg.subscribe()
 	pg.loadTransactions()
 }
 
+// selectedWallet returns the wallet selected in the wallet dropdown, or nil
+// if all wallets are selected.
+func (pg *TransactionsPage) selectedWallet() *dcrlibwallet.Wallet {
+	index := pg.walletDropDown.SelectedIndex()
+	if len(pg.wallets) > 1 {
+		if index == 0 {
+			return nil
+		}
+		index--
+	}
+	return pg.wallets[index]
+}
+
+// loadTransactions loads the first page of the transactions of the selected
+// wallet, or of all wallets merged by time. Later pages are loaded as the
+// list is scrolled.
 func (pg *TransactionsPage) loadTransactions() {
-	selectedWallet := pg.wallets[pg.walletDropDown.SelectedIndex()]
 	newestFirst := pg.orderDropDown.SelectedIndex() == 0
+	txFilter := pg.txFilter()
+
+	pg.transactionList.Position = layout.Position{}
+	selectedWallet := pg.selectedWallet()
+	if selectedWallet == nil {
+		wallets := pg.wallets
+		merged := newMergedTxFetcher(wallets, txFilter, newestFirst)
+		pg.pager.reset(
+			merged.fetch,
+			func(tx *dcrlibwallet.Transaction) bool {
+				for _, wal := range wallets {
+					if wal.ID == tx.WalletID {
+						return wal.TxMatchesFilter(tx, txFilter)
+					}
+				}
+				return false
+			},
+			merged.inserted,
+			newestFirst,
+		)
+		return
+	}
 
+	pg.pager.reset(
+		func(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+			return selectedWallet.GetTransactionsRaw(offset, limit, txFilter, newestFirst)
+		},
+		func(tx *dcrlibwallet.Transaction) bool {
+			return tx.WalletID == selectedWallet.ID && selectedWallet.TxMatchesFilter(tx, txFilter)
+		},
+		nil,
+		newestFirst,
+	)
+}
+
+// txFilter returns the dcrlibwallet filter for the selected transaction type.
+func (pg *TransactionsPage) txFilter() int32 {
 	txFilter := dcrlibwallet.TxFilterAll
 	switch pg.txTypeDropDown.SelectedIndex() {
 	case 1:
@@ -97,17 +149,19 @@ func (pg *TransactionsPage) loadTransactions() {
 		txFilter = dcrlibwallet.TxFilterStaking
 	}
 
-	wallTxs, err := selectedWallet.GetTransactionsRaw(0, 0, txFilter, newestFirst) //TODO
-	if err != nil {
-		log.Error("Error loading transactions:", err)
-	} else {
-		pg.transactions = wallTxs
-	}
+	return txFilter
 }
 
 func (pg *TransactionsPage) Layout(gtx layout.Context) layout.Dimensions {
+	if err := pg.pager.update(&pg.transactionList.List); err != nil {
+		log.Error("Error loading transactions:", err)
+		pg.Toast.NotifyError(err.Error())
+	}
+
 	container := func(gtx C) D {
-		wallTxs := pg.transactions
+		wallTxs := pg.pager.transactions()
+		allWallets := pg.selectedWallet() == nil
+		loading := pg.pager.isLoading()
 		return layout.Stack{Alignment: layout.N}.Layout(gtx,
 			layout.Expanded(func(gtx C) D {
 				return layout.Inset{
@@ -116,7 +170,7 @@ func (pg *TransactionsPage) Layout(gtx layout.Context) layout.Dimensions {
 					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
 
 						// return "No transactions yet" text if there are no transactions
-						if len(wallTxs) == 0 {
+						if len(wallTxs) == 0 && !loading {
 							padding := values.MarginPadding16
 							gtx.Constraints.Min.X = gtx.Constraints.Max.X
 							txt := pg.Theme.Body1(values.String(values.StrNoTransactionsYet))
@@ -126,11 +180,19 @@ func (pg *TransactionsPage) Layout(gtx layout.Context) layout.Dimensions {
 							})
 						}
 
-						return pg.transactionList.Layout(gtx, len(wallTxs), func(gtx C, index int) D {
+						count := len(wallTxs)
+						if loading {
+							count++
+						}
+						return pg.transactionList.Layout(gtx, count, func(gtx C, index int) D {
+							if index == len(wallTxs) {
+								return pg.layoutLoading(gtx)
+							}
+
 							var row = components.TransactionRow{
 								Transaction: wallTxs[index],
 								Index:       index,
-								ShowBadge:   false,
+								ShowBadge:   allWallets,
 							}
 
 							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -139,7 +201,7 @@ func (pg *TransactionsPage) Layout(gtx layout.Context) layout.Dimensions {
 								}),
 								layout.Rigid(func(gtx C) D {
 									// No divider for last row
-									if row.Index == len(wallTxs)-1 {
+									if row.Index == count-1 {
 										return layout.Dimensions{}
 									}
 
@@ -155,6 +217,19 @@ func (pg *TransactionsPage) Layout(gtx layout.Context) layout.Dimensions {
 					})
 				})
 			}),
+			layout.Expanded(func(gtx C) D {
+				// laid out before the dropdowns so an open dropdown covers it
+				right := unit.Dp(float32(pg.orderDropDown.Width + pg.txTypeDropDown.Width + 10))
+				return layout.NE.Layout(gtx, func(gtx C) D {
+					return layout.Inset{Right: right}.Layout(gtx, func(gtx C) D {
+						return layout.Flex{}.Layout(gtx,
+							layout.Rigid(pg.searchButton.Layout),
+							layout.Rigid(layout.Spacer{Width: values.MarginPadding8}.Layout),
+							layout.Rigid(pg.exportButton.Layout),
+						)
+					})
+				})
+			}),
 			layout.Expanded(func(gtx C) D {
 				return pg.walletDropDown.Layout(gtx, 0, false)
 			}),
@@ -169,6 +244,17 @@ func (pg *TransactionsPage) Layout(gtx layout.Context) layout.Dimensions {
 	return components.UniformPadding(gtx, container)
 }
 
+// layoutLoading lays out the row shown at the end of the list while a page
+// of transactions is loading.
+func (pg *TransactionsPage) layoutLoading(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	txt := pg.Theme.Body2("Loading transactions...")
+	txt.Color = pg.Theme.Color.Gray2
+	return layout.Center.Layout(gtx, func(gtx C) D {
+		return layout.UniformInset(values.MarginPadding16).Layout(gtx, txt.Layout)
+	})
+}
+
 func (pg *TransactionsPage) Handle() {
 
 	for pg.txTypeDropDown.Changed() {
@@ -183,34 +269,39 @@ func (pg *TransactionsPage) Handle() {
 		pg.loadTransactions()
 	}
 
+	if pg.exportButton.Clicked() {
+		selectedWallet := pg.selectedWallet()
+		newestFirst := pg.orderDropDown.SelectedIndex() == 0
+		if selectedWallet == nil {
+			exportModal := newTxExportModal(pg.Load, pg.wallets[0], pg.wallets, pg.txFilter(), newestFirst)
+			exportModal.allWallets.CheckBox.Value = true
+			exportModal.Show()
+		} else {
+			newTxExportModal(pg.Load, selectedWallet, pg.wallets, pg.txFilter(), newestFirst).Show()
+		}
+	}
+
+	if pg.searchButton.Clicked() {
+		pg.ChangeFragment(NewTxSearchPage(pg.Load))
+	}
+
 	if clicked, selectedItem := pg.transactionList.ItemClicked(); clicked {
-		pg.ChangeFragment(NewTransactionDetailsPage(pg.Load, &pg.transactions[selectedItem]))
+		if txs := pg.pager.transactions(); selectedItem < len(txs) {
+			tx := txs[selectedItem]
+			pg.ChangeFragment(NewTransactionDetailsPage(pg.Load, &tx))
+		}
 	}
 }
 
-func (pg *TransactionsPage) listenForTxNotifications() {
-	go func() {
-		for {
-			var notification interface{}
-
-			select {
-			case notification = <-pg.Receiver.NotificationsUpdate:
-			case <-pg.ctx.Done():
-				return
-			}
-
-			switch n := notification.(type) {
-			case wallet.NewTransaction:
-				selectedWallet := pg.wallets[pg.walletDropDown.SelectedIndex()]
-				if selectedWallet.ID == n.Transaction.WalletID {
-					pg.loadTransactions()
-					pg.RefreshWindow()
-				}
-			}
-		}
-	}()
+// subscribe adds the new transactions of the selected wallets to the list.
+func (pg *TransactionsPage) subscribe() {
+	pg.events = pg.WL.Wallet.Events().Subscribe(wallet.EventHandlers{
+		OnTransaction: func(tx wallet.NewTransaction) {
+			pg.pager.addTransaction(*tx.Transaction)
+		},
+	})
 }
 
 func (pg *TransactionsPage) OnClose() {
-	pg.ctxCancel()
+	pg.events.Unsubscribe()
 }
@@ -0,0 +1,251 @@
+package page
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const txExportModalID = "tx_export_modal"
+
+// txExportResult is the outcome of an export, sent from the export goroutine
+// to Handle.
+type txExportResult struct {
+	path  string
+	count int
+	err   error
+}
+
+// txExportModal writes the transactions matching the filter of the
+// transactions page to a CSV or JSON file.
+type txExportModal struct {
+	*load.Load
+
+	modal       decredmaterial.Modal
+	wallet      *dcrlibwallet.Wallet
+	wallets     []*dcrlibwallet.Wallet
+	txFilter    int32
+	newestFirst bool
+	isExporting bool
+	exported    chan txExportResult
+
+	format     *widget.Enum
+	csvFormat  decredmaterial.RadioButton
+	jsonFormat decredmaterial.RadioButton
+	allWallets decredmaterial.CheckBoxStyle
+	filePath   decredmaterial.Editor
+	cancel     decredmaterial.Button
+	export     decredmaterial.Button
+}
+
+func newTxExportModal(l *load.Load, wal *dcrlibwallet.Wallet, wallets []*dcrlibwallet.Wallet, txFilter int32, newestFirst bool) *txExportModal {
+	em := &txExportModal{
+		Load:        l,
+		modal:       *l.Theme.ModalFloatTitle(),
+		wallet:      wal,
+		wallets:     wallets,
+		txFilter:    txFilter,
+		newestFirst: newestFirst,
+		exported:    make(chan txExportResult, 1),
+
+		format:     &widget.Enum{Value: wallet.TxExportCSV},
+		allWallets: l.Theme.CheckBox(new(widget.Bool), values.String(values.StrAllWallets)),
+		filePath:   l.Theme.Editor(new(widget.Editor), values.String(values.StrExportFile)),
+		cancel:     l.Theme.OutlineButton(values.String(values.StrCancel)),
+		export:     l.Theme.Button(values.String(values.StrExport)),
+	}
+
+	em.csvFormat = l.Theme.RadioButton(em.format, wallet.TxExportCSV, "CSV", l.Theme.Color.DeepBlue)
+	em.jsonFormat = l.Theme.RadioButton(em.format, wallet.TxExportJSON, "JSON", l.Theme.Color.DeepBlue)
+
+	em.filePath.Editor.SingleLine = true
+	fileName := fmt.Sprintf("transactions-%s.%s", time.Now().Format("20060102-150405"), wallet.TxExportCSV)
+	em.filePath.Editor.SetText(filepath.Join(l.WL.Wallet.Root, "exports", fileName))
+
+	return em
+}
+
+func (em *txExportModal) ModalID() string {
+	return txExportModalID
+}
+
+func (em *txExportModal) Show() {
+	em.ShowModal(em)
+}
+
+func (em *txExportModal) Dismiss() {
+	em.DismissModal(em)
+}
+
+func (em *txExportModal) OnResume() {}
+
+func (em *txExportModal) OnDismiss() {}
+
+func (em *txExportModal) Handle() {
+	for em.format.Changed() {
+		path := em.filePath.Editor.Text()
+		path = strings.TrimSuffix(path, filepath.Ext(path)) + "." + em.format.Value
+		em.filePath.Editor.SetText(path)
+	}
+
+	isSubmit, isChanged := decredmaterial.HandleEditorEvents(em.filePath.Editor)
+	if isChanged {
+		em.filePath.SetError("")
+	}
+
+	em.export.SetEnabled(!em.isExporting && strings.TrimSpace(em.filePath.Editor.Text()) != "")
+
+	if (em.export.Clicked() || isSubmit) && em.export.Enabled() {
+		em.isExporting = true
+		format, path, allWallets := em.format.Value, strings.TrimSpace(em.filePath.Editor.Text()), em.allWallets.CheckBox.Value
+		go func() {
+			count, err := em.exportTransactions(format, path, allWallets)
+			em.exported <- txExportResult{path: path, count: count, err: err}
+			em.RefreshWindow()
+		}()
+	}
+
+	select {
+	case result := <-em.exported:
+		em.isExporting = false
+		if result.err != nil {
+			em.filePath.SetError(result.err.Error())
+		} else {
+			em.Toast.Notify(values.StringF(values.StrTransactionsExported, result.count, result.path))
+			em.Dismiss()
+		}
+	default:
+	}
+
+	if em.cancel.Clicked() && !em.isExporting {
+		em.Dismiss()
+	}
+
+	if em.modal.BackdropClicked(!em.isExporting) {
+		em.Dismiss()
+	}
+}
+
+// exportTransactions writes the transactions to path in format and returns
+// the number of transactions written. It runs outside the UI goroutine.
+func (em *txExportModal) exportTransactions(format, path string, allWallets bool) (int, error) {
+	records, err := em.exportRecords(allWallets)
+	if err != nil {
+		log.Errorf("Error loading transactions for export: %v", err)
+		return 0, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	err = wallet.ExportTransactions(f, format, records)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Errorf("Error exporting transactions to %s: %v", path, err)
+		return 0, err
+	}
+
+	return len(records), nil
+}
+
+// exportRecords loads the transactions of the selected wallet, or of all
+// wallets, and converts them to export records valued at the current
+// exchange rate.
+func (em *txExportModal) exportRecords(allWallets bool) ([]wallet.TxExportRecord, error) {
+	var rate *wallet.ExchangeRate
+	if currency := em.WL.ExchangeCurrency(); currency != "" {
+		exchangeRate, err := em.WL.Wallet.ExchangeRate(currency)
+		if err != nil {
+			log.Warnf("Exporting transactions without fiat values: %v", err)
+		} else {
+			rate = &exchangeRate
+		}
+	}
+
+	wallets := []*dcrlibwallet.Wallet{em.wallet}
+	if allWallets {
+		wallets = em.wallets
+	}
+
+	var records []wallet.TxExportRecord
+	for _, wal := range wallets {
+		txs, err := wal.GetTransactionsRaw(0, 0, em.txFilter, em.newestFirst)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range txs {
+			records = append(records, wallet.NewTxExportRecord(wal, tx, rate))
+		}
+	}
+
+	if len(wallets) > 1 {
+		sort.SliceStable(records, func(i, j int) bool {
+			if em.newestFirst {
+				return records[i].Timestamp.After(records[j].Timestamp)
+			}
+			return records[i].Timestamp.Before(records[j].Timestamp)
+		})
+	}
+
+	return records, nil
+}
+
+func (em *txExportModal) Layout(gtx layout.Context) D {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := em.Theme.H6(values.String(values.StrExportTransactions))
+			t.Font.Weight = text.Bold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(em.csvFormat.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, em.jsonFormat.Layout)
+				}),
+			)
+		},
+		func(gtx C) D {
+			if len(em.wallets) < 2 {
+				return D{}
+			}
+			return em.allWallets.Layout(gtx)
+		},
+		em.filePath.Layout,
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, em.cancel.Layout)
+					}),
+					layout.Rigid(em.export.Layout),
+				)
+			})
+		},
+	}
+
+	return em.modal.Layout(gtx, w, 850)
+}
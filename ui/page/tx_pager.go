@@ -0,0 +1,440 @@
+package page
+
+import (
+	"sync"
+
+	"gioui.org/layout"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// txPageSize is the number of transactions loaded at a time.
+	txPageSize = 50
+
+	// maxCachedTxs is the number of transactions kept in memory. Pages that
+	// scroll out of view beyond it are evicted and loaded again when they
+	// scroll back into view.
+	maxCachedTxs = 300
+
+	// txLoadThreshold is the number of rows left before either end of the
+	// cached transactions at which the next page starts loading.
+	txLoadThreshold = 10
+)
+
+// txFetcher returns limit transactions of a list, starting at offset.
+type txFetcher func(offset, limit int32) ([]dcrlibwallet.Transaction, error)
+
+// txPageResult is a page of transactions loaded in the background. It is
+// applied to the cache on the UI goroutine so the list position can be
+// adjusted with it.
+type txPageResult struct {
+	generation int
+	offset     int32
+	limit      int32
+	txs        []dcrlibwallet.Transaction
+	err        error
+}
+
+// txPager loads a transaction list page by page as it is scrolled, keeping a
+// window of at most maxCachedTxs transactions in memory. New transactions are
+// added to the window without reloading it.
+type txPager struct {
+	refresh func()
+
+	mu          sync.Mutex
+	fetch       txFetcher
+	matches     func(*dcrlibwallet.Transaction) bool
+	inserted    func(*dcrlibwallet.Transaction)
+	newestFirst bool
+	generation  int
+	loading     bool
+	failed      bool
+	pending     []txPageResult
+	newTxs      []dcrlibwallet.Transaction
+
+	// start is the offset of the first cached transaction in the list. The
+	// cached transactions are only replaced, never modified, so the slice
+	// can be laid out while a page is loading.
+	start int32
+	txs   []dcrlibwallet.Transaction
+	atEnd bool
+}
+
+func newTxPager(refresh func()) *txPager {
+	return &txPager{refresh: refresh}
+}
+
+// reset empties the cache and loads the first page of the list returned by
+// fetch. matches reports whether a new transaction belongs in the list and
+// inserted, if not nil, is called when one is inserted at the start of it.
+func (p *txPager) reset(fetch txFetcher, matches func(*dcrlibwallet.Transaction) bool, inserted func(*dcrlibwallet.Transaction), newestFirst bool) {
+	p.mu.Lock()
+	p.fetch = fetch
+	p.matches = matches
+	p.inserted = inserted
+	p.newestFirst = newestFirst
+	p.generation++
+	p.loading = false
+	p.failed = false
+	p.pending = nil
+	p.newTxs = nil
+	p.start = 0
+	p.txs = nil
+	p.atEnd = false
+	p.mu.Unlock()
+
+	p.load(0, txPageSize)
+}
+
+// load fetches limit transactions starting at offset in the background. Only
+// one page is loaded at a time.
+func (p *txPager) load(offset, limit int32) {
+	p.mu.Lock()
+	if p.loading || p.fetch == nil {
+		p.mu.Unlock()
+		return
+	}
+	p.loading = true
+	fetch, generation := p.fetch, p.generation
+	p.mu.Unlock()
+
+	go func() {
+		txs, err := fetch(offset, limit)
+
+		p.mu.Lock()
+		if generation == p.generation {
+			p.pending = append(p.pending, txPageResult{
+				generation: generation,
+				offset:     offset,
+				limit:      limit,
+				txs:        txs,
+				err:        err,
+			})
+		}
+		p.mu.Unlock()
+		p.refresh()
+	}()
+}
+
+// addTransaction adds a new transaction to the list if it matches the
+// current filter. It is safe to call from any goroutine.
+func (p *txPager) addTransaction(tx dcrlibwallet.Transaction) {
+	p.mu.Lock()
+	if p.matches == nil || !p.matches(&tx) {
+		p.mu.Unlock()
+		return
+	}
+	p.newTxs = append(p.newTxs, tx)
+	p.mu.Unlock()
+	p.refresh()
+}
+
+// transactions returns the cached transactions.
+func (p *txPager) transactions() []dcrlibwallet.Transaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.txs
+}
+
+// isLoading returns true while a page is loading.
+func (p *txPager) isLoading() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.loading
+}
+
+// update applies the loaded pages and new transactions to the cache and
+// loads the next or previous page when list is scrolled near either end of
+// the cache. It returns the error of a page that failed to load, after which
+// no more pages are loaded until the pager is reset. It must be called on the
+// UI goroutine before list is laid out.
+func (p *txPager) update(list *layout.List) error {
+	p.mu.Lock()
+	var err error
+	for _, result := range p.pending {
+		p.loading = false
+		if result.generation != p.generation {
+			continue
+		}
+		if result.err != nil {
+			err = result.err
+			p.failed = true
+			continue
+		}
+		if result.offset < p.start {
+			p.prependPage(list, result)
+		} else {
+			p.appendPage(list, result)
+		}
+	}
+	p.pending = nil
+
+	for _, tx := range p.newTxs {
+		p.insertTransaction(list, tx)
+	}
+	p.newTxs = nil
+
+	first, last := list.Position.First, list.Position.First+list.Position.Count
+	var next, previous bool
+	if !p.loading && !p.failed {
+		next = !p.atEnd && last >= len(p.txs)-txLoadThreshold
+		previous = p.start > 0 && first <= txLoadThreshold
+	}
+	start, end := p.start, p.start+int32(len(p.txs))
+	p.mu.Unlock()
+
+	switch {
+	case next:
+		p.load(end, txPageSize)
+	case previous:
+		offset := start - txPageSize
+		if offset < 0 {
+			offset = 0
+		}
+		p.load(offset, start-offset)
+	}
+
+	return err
+}
+
+// appendPage adds a page to the end of the cache and evicts the rows that
+// have scrolled out of view from the start of it. It must be called with
+// the lock held.
+func (p *txPager) appendPage(list *layout.List, result txPageResult) {
+	if result.offset != p.start+int32(len(p.txs)) {
+		return
+	}
+
+	txs := make([]dcrlibwallet.Transaction, 0, len(p.txs)+len(result.txs))
+	txs = append(txs, p.txs...)
+	txs = append(txs, result.txs...)
+	p.atEnd = int32(len(result.txs)) < result.limit
+
+	if evict := len(txs) - maxCachedTxs; evict > 0 {
+		if visible := list.Position.First - txLoadThreshold; evict > visible {
+			evict = visible
+		}
+		if evict > 0 {
+			txs = txs[evict:]
+			p.start += int32(evict)
+			list.Position.First -= evict
+		}
+	}
+	p.txs = txs
+}
+
+// prependPage adds a page to the start of the cache and evicts the rows that
+// have scrolled out of view from the end of it. It must be called with the
+// lock held.
+func (p *txPager) prependPage(list *layout.List, result txPageResult) {
+	if result.offset+int32(len(result.txs)) != p.start {
+		return
+	}
+
+	txs := make([]dcrlibwallet.Transaction, 0, len(p.txs)+len(result.txs))
+	txs = append(txs, result.txs...)
+	txs = append(txs, p.txs...)
+	p.start = result.offset
+	list.Position.First += len(result.txs)
+
+	if len(txs) > maxCachedTxs {
+		keep := maxCachedTxs
+		if visible := list.Position.First + list.Position.Count + txLoadThreshold; keep < visible {
+			keep = visible
+		}
+		if keep < len(txs) {
+			txs = txs[:keep]
+			p.atEnd = false
+		}
+	}
+	p.txs = txs
+}
+
+// insertTransaction adds a new transaction to the cache, or replaces it if
+// it is cached already. A transaction that belongs outside the cached window
+// only shifts the offset of the window. It must be called with the lock
+// held.
+func (p *txPager) insertTransaction(list *layout.List, tx dcrlibwallet.Transaction) {
+	for i := range p.txs {
+		if p.txs[i].Hash == tx.Hash {
+			txs := make([]dcrlibwallet.Transaction, len(p.txs))
+			copy(txs, p.txs)
+			txs[i] = tx
+			p.txs = txs
+			return
+		}
+	}
+
+	if !p.newestFirst {
+		// new transactions are at the end of the list, which is loaded
+		// when it is scrolled to.
+		p.atEnd = false
+		return
+	}
+
+	if p.inserted != nil {
+		p.inserted(&tx)
+	}
+
+	if p.start > 0 {
+		p.start++
+		return
+	}
+
+	txs := make([]dcrlibwallet.Transaction, 0, len(p.txs)+1)
+	txs = append(txs, tx)
+	txs = append(txs, p.txs...)
+	p.txs = txs
+
+	// keep the rows in view where they are unless the list is scrolled to
+	// the top, where the new transaction is shown.
+	if list.Position.First > 0 || list.Position.Offset > 0 {
+		list.Position.First++
+	}
+}
+
+// mergedTxFetcher lists the transactions of several wallets as one list
+// ordered by time. The wallets are read with their own offsets, so the
+// offset of each wallet is recorded at the start and end of every page to
+// read the next or previous page without merging the list from its start.
+type mergedTxFetcher struct {
+	walletIDs   []int
+	lists       []txFetcher // the transactions of each wallet
+	newestFirst bool
+
+	mu sync.Mutex
+	// cursors are the wallet offsets by list offset.
+	cursors map[int32][]int32
+	// version changes when the cursors are shifted, so a page read with
+	// the old cursors does not record its own.
+	version int
+}
+
+func newMergedTxFetcher(wallets []*dcrlibwallet.Wallet, txFilter int32, newestFirst bool) *mergedTxFetcher {
+	walletIDs := make([]int, len(wallets))
+	lists := make([]txFetcher, len(wallets))
+	for i, wal := range wallets {
+		wal := wal
+		walletIDs[i] = wal.ID
+		lists[i] = func(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+			return wal.GetTransactionsRaw(offset, limit, txFilter, newestFirst)
+		}
+	}
+	return newMergedFetcher(walletIDs, lists, newestFirst)
+}
+
+// newMergedFetcher returns a fetcher that merges lists, the transaction
+// lists of the wallets with walletIDs in the same order.
+func newMergedFetcher(walletIDs []int, lists []txFetcher, newestFirst bool) *mergedTxFetcher {
+	return &mergedTxFetcher{
+		walletIDs:   walletIDs,
+		lists:       lists,
+		newestFirst: newestFirst,
+		cursors:     map[int32][]int32{0: make([]int32, len(lists))},
+	}
+}
+
+// fetch returns limit transactions of the merged list starting at offset.
+func (f *mergedTxFetcher) fetch(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+	f.mu.Lock()
+	version := f.version
+	// start merging from the nearest recorded offset before the page.
+	from := int32(0)
+	for cursorOffset := range f.cursors {
+		if cursorOffset <= offset && cursorOffset > from {
+			from = cursorOffset
+		}
+	}
+	positions := append([]int32(nil), f.cursors[from]...)
+	f.mu.Unlock()
+
+	skip := offset - from
+	need := skip + limit
+	lists := make([][]dcrlibwallet.Transaction, len(f.lists))
+	for i, list := range f.lists {
+		txs, err := list(positions[i], need)
+		if err != nil {
+			return nil, err
+		}
+		lists[i] = txs
+	}
+
+	var pageStart []int32
+	txs := make([]dcrlibwallet.Transaction, 0, limit)
+	next := make([]int, len(lists))
+	for n := int32(0); n < need; n++ {
+		if n == skip {
+			pageStart = append([]int32(nil), positions...)
+		}
+
+		// take the next transaction in time from any wallet, the first
+		// wallet first when they are at the same time.
+		best := -1
+		for i, list := range lists {
+			if next[i] == len(list) {
+				continue
+			}
+			if best == -1 || f.before(&list[next[i]], &lists[best][next[best]]) {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+
+		if n >= skip {
+			txs = append(txs, lists[best][next[best]])
+		}
+		next[best]++
+		positions[best]++
+	}
+
+	f.mu.Lock()
+	if version == f.version {
+		if pageStart != nil {
+			f.cursors[offset] = pageStart
+		}
+		f.cursors[offset+int32(len(txs))] = positions
+	}
+	f.mu.Unlock()
+
+	return txs, nil
+}
+
+// before returns true if a is listed before b.
+func (f *mergedTxFetcher) before(a, b *dcrlibwallet.Transaction) bool {
+	if f.newestFirst {
+		return a.Timestamp > b.Timestamp
+	}
+	return a.Timestamp < b.Timestamp
+}
+
+// inserted shifts the recorded offsets after a new transaction is inserted
+// at the start of the list.
+func (f *mergedTxFetcher) inserted(tx *dcrlibwallet.Transaction) {
+	index := -1
+	for i, walletID := range f.walletIDs {
+		if walletID == tx.WalletID {
+			index = i
+		}
+	}
+	if index == -1 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cursors := map[int32][]int32{0: make([]int32, len(f.lists))}
+	for offset, positions := range f.cursors {
+		if offset == 0 {
+			continue
+		}
+		positions = append([]int32(nil), positions...)
+		positions[index]++
+		cursors[offset+1] = positions
+	}
+	f.cursors = cursors
+	f.version++
+}
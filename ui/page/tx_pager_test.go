@@ -0,0 +1,303 @@
+package page
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"gioui.org/layout"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// testWalletTxs is the transaction list of a wallet, newest first.
+type testWalletTxs struct {
+	txs    []dcrlibwallet.Transaction
+	limits []int32 // the limit of each read
+}
+
+func (w *testWalletTxs) fetch(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+	w.limits = append(w.limits, limit)
+	if offset >= int32(len(w.txs)) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > int32(len(w.txs)) {
+		end = int32(len(w.txs))
+	}
+	return w.txs[offset:end], nil
+}
+
+// newTestWalletTxs returns n transactions of a wallet at every step seconds
+// from start, newest first.
+func newTestWalletTxs(walletID, n int, start, step int64) *testWalletTxs {
+	w := new(testWalletTxs)
+	for i := n - 1; i >= 0; i-- {
+		w.txs = append(w.txs, dcrlibwallet.Transaction{
+			WalletID:  walletID,
+			Hash:      fmt.Sprintf("%d-%d", walletID, i),
+			Timestamp: start + int64(i)*step,
+		})
+	}
+	return w
+}
+
+// mergeTestTxs returns the transactions of all wallets newest first, the
+// first wallet first when they are at the same time.
+func mergeTestTxs(wallets ...*testWalletTxs) []dcrlibwallet.Transaction {
+	var txs []dcrlibwallet.Transaction
+	for _, w := range wallets {
+		txs = append(txs, w.txs...)
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Timestamp > txs[j].Timestamp
+	})
+	return txs
+}
+
+func newTestMergedFetcher(wallets ...*testWalletTxs) *mergedTxFetcher {
+	walletIDs := make([]int, len(wallets))
+	lists := make([]txFetcher, len(wallets))
+	for i, w := range wallets {
+		walletIDs[i] = w.txs[0].WalletID
+		lists[i] = w.fetch
+	}
+	return newMergedFetcher(walletIDs, lists, true)
+}
+
+func hashes(txs []dcrlibwallet.Transaction) []string {
+	list := make([]string, len(txs))
+	for i := range txs {
+		list[i] = txs[i].Hash
+	}
+	return list
+}
+
+func TestMergedTxFetcher(t *testing.T) {
+	// the second wallet has transactions at the same time as the first
+	wallets := []*testWalletTxs{
+		newTestWalletTxs(1, 120, 1000, 3),
+		newTestWalletTxs(2, 80, 1000, 6),
+		newTestWalletTxs(3, 10, 5000, 1),
+	}
+	want := mergeTestTxs(wallets...)
+	f := newTestMergedFetcher(wallets...)
+
+	pages := []struct {
+		offset, limit int32
+	}{
+		{0, 50}, {50, 50}, {100, 50}, {150, 50}, // scrolling down
+		{50, 50}, {0, 50}, // scrolling back up
+		{120, 30}, {175, 50}, // offsets between the recorded ones
+		{210, 50}, // past the end
+	}
+	for _, page := range pages {
+		txs, err := f.fetch(page.offset, page.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		start, end := page.offset, page.offset+page.limit
+		if start > int32(len(want)) {
+			start = int32(len(want))
+		}
+		if end > int32(len(want)) {
+			end = int32(len(want))
+		}
+		if !reflect.DeepEqual(hashes(txs), hashes(want[start:end])) {
+			t.Fatalf("page at %d: got %v, want %v", page.offset, hashes(txs), hashes(want[start:end]))
+		}
+	}
+
+	// pages after a recorded offset only read the page from each wallet
+	for i, w := range wallets {
+		if limits := w.limits[:4]; !reflect.DeepEqual(limits, []int32{50, 50, 50, 50}) {
+			t.Errorf("wallet %d: got limits %v when scrolling down, want 50 for each page", i, limits)
+		}
+	}
+}
+
+func TestMergedTxFetcherInserted(t *testing.T) {
+	wallets := []*testWalletTxs{
+		newTestWalletTxs(1, 60, 1000, 2),
+		newTestWalletTxs(2, 60, 1001, 2),
+	}
+	f := newTestMergedFetcher(wallets...)
+	for offset := int32(0); offset < 120; offset += 50 {
+		if _, err := f.fetch(offset, 50); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a new transaction of the second wallet shifts the recorded offsets
+	tx := dcrlibwallet.Transaction{WalletID: 2, Hash: "new", Timestamp: 9000}
+	wallets[1].txs = append([]dcrlibwallet.Transaction{tx}, wallets[1].txs...)
+	f.inserted(&tx)
+
+	want := mergeTestTxs(wallets...)
+	for _, offset := range []int32{51, 101, 0} {
+		txs, err := f.fetch(offset, 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		end := offset + 50
+		if end > int32(len(want)) {
+			end = int32(len(want))
+		}
+		if !reflect.DeepEqual(hashes(txs), hashes(want[offset:end])) {
+			t.Fatalf("page at %d: got %v, want %v", offset, hashes(txs), hashes(want[offset:end]))
+		}
+	}
+
+	// transactions of other wallets are ignored
+	version := f.version
+	f.inserted(&dcrlibwallet.Transaction{WalletID: 7})
+	if f.version != version {
+		t.Fatalf("cursors shifted for a wallet that is not listed")
+	}
+}
+
+func testTxs(offset, n int) []dcrlibwallet.Transaction {
+	txs := make([]dcrlibwallet.Transaction, n)
+	for i := range txs {
+		txs[i].Hash = fmt.Sprint(offset + i)
+	}
+	return txs
+}
+
+func TestTxPagerAppendPage(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     int
+		pageSize  int
+		wantStart int32
+		wantLen   int
+		wantFirst int
+		wantAtEnd bool
+	}{{
+		name:      "rows above the view are evicted",
+		first:     280,
+		pageSize:  txPageSize,
+		wantStart: 50,
+		wantLen:   maxCachedTxs,
+		wantFirst: 230,
+	}, {
+		name:      "rows near the view are kept",
+		first:     30,
+		pageSize:  txPageSize,
+		wantStart: 20,
+		wantLen:   330,
+		wantFirst: 10,
+	}, {
+		name:      "last page",
+		first:     280,
+		pageSize:  20,
+		wantStart: 20,
+		wantLen:   maxCachedTxs,
+		wantFirst: 260,
+		wantAtEnd: true,
+	}}
+
+	for _, test := range tests {
+		p := &txPager{txs: testTxs(0, maxCachedTxs)}
+		list := &layout.List{Position: layout.Position{First: test.first, Count: 10}}
+		p.appendPage(list, txPageResult{offset: maxCachedTxs, limit: txPageSize, txs: testTxs(maxCachedTxs, test.pageSize)})
+
+		if p.start != test.wantStart || len(p.txs) != test.wantLen || list.Position.First != test.wantFirst {
+			t.Errorf("%s: got start %d, %d txs, first row %d, want start %d, %d txs, first row %d", test.name,
+				p.start, len(p.txs), list.Position.First, test.wantStart, test.wantLen, test.wantFirst)
+			continue
+		}
+		if p.atEnd != test.wantAtEnd {
+			t.Errorf("%s: got at end %v, want %v", test.name, p.atEnd, test.wantAtEnd)
+		}
+		if first := p.txs[list.Position.First].Hash; first != fmt.Sprint(test.first) {
+			t.Errorf("%s: got %s in view, want %d", test.name, first, test.first)
+		}
+	}
+
+	// a page that does not follow the cache is dropped
+	p := &txPager{txs: testTxs(0, 10)}
+	p.appendPage(&layout.List{}, txPageResult{offset: 20, limit: txPageSize, txs: testTxs(20, 10)})
+	if len(p.txs) != 10 {
+		t.Errorf("page not following the cache added")
+	}
+}
+
+func TestTxPagerPrependPage(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		wantLen   int
+		wantAtEnd bool
+	}{{
+		name:    "rows below the view are evicted",
+		count:   10,
+		wantLen: maxCachedTxs,
+	}, {
+		name:      "rows near the view are kept",
+		count:     285,
+		wantLen:   350,
+		wantAtEnd: true,
+	}}
+
+	for _, test := range tests {
+		p := &txPager{start: 50, txs: testTxs(50, maxCachedTxs), atEnd: true}
+		list := &layout.List{Position: layout.Position{First: 5, Count: test.count}}
+		p.prependPage(list, txPageResult{offset: 0, limit: 50, txs: testTxs(0, 50)})
+
+		if p.start != 0 || len(p.txs) != test.wantLen || list.Position.First != 55 {
+			t.Errorf("%s: got start %d, %d txs, first row %d, want start 0, %d txs, first row 55", test.name,
+				p.start, len(p.txs), list.Position.First, test.wantLen)
+			continue
+		}
+		if p.atEnd != test.wantAtEnd {
+			t.Errorf("%s: got at end %v, want %v", test.name, p.atEnd, test.wantAtEnd)
+		}
+		if first := p.txs[list.Position.First].Hash; first != "55" {
+			t.Errorf("%s: got %s in view, want 55", test.name, first)
+		}
+	}
+
+	// a page that does not end at the start of the cache is dropped
+	p := &txPager{start: 100, txs: testTxs(100, 10)}
+	p.prependPage(&layout.List{}, txPageResult{offset: 0, limit: 50, txs: testTxs(0, 50)})
+	if p.start != 100 || len(p.txs) != 10 {
+		t.Errorf("page not ending at the cache added")
+	}
+}
+
+func TestTxPagerInsertTransaction(t *testing.T) {
+	var inserted []string
+	p := &txPager{
+		newestFirst: true,
+		txs:         testTxs(0, 5),
+		inserted: func(tx *dcrlibwallet.Transaction) {
+			inserted = append(inserted, tx.Hash)
+		},
+	}
+
+	// a cached transaction is replaced
+	list := &layout.List{Position: layout.Position{First: 2}}
+	p.insertTransaction(list, dcrlibwallet.Transaction{Hash: "3", BlockHeight: 10})
+	if len(p.txs) != 5 || p.txs[3].BlockHeight != 10 || inserted != nil {
+		t.Fatalf("cached transaction not replaced")
+	}
+
+	// a new one is added at the start, keeping the rows in view
+	p.insertTransaction(list, dcrlibwallet.Transaction{Hash: "new"})
+	if len(p.txs) != 6 || p.txs[0].Hash != "new" || list.Position.First != 3 {
+		t.Fatalf("got %v with first row %d, want the new transaction first", hashes(p.txs), list.Position.First)
+	}
+
+	// outside the cached window it only shifts the window
+	p.start = 50
+	p.insertTransaction(list, dcrlibwallet.Transaction{Hash: "newer"})
+	if p.start != 51 || len(p.txs) != 6 {
+		t.Fatalf("got start %d with %d txs, want start 51 with 6", p.start, len(p.txs))
+	}
+	if !reflect.DeepEqual(inserted, []string{"new", "newer"}) {
+		t.Fatalf("got inserted %v", inserted)
+	}
+}
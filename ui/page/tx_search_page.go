@@ -0,0 +1,362 @@
+package page
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const TxSearchPageID = "TransactionSearch"
+
+// maxTxSearchResults is the number of search results listed.
+const maxTxSearchResults = 200
+
+const txSearchDateFormat = "2006-01-02"
+
+// TxSearchPage searches the transactions of all wallets by hash, address,
+// amount, date, wallet and account.
+type TxSearchPage struct {
+	*load.Load
+
+	wallets []*dcrlibwallet.Wallet
+	results []wallet.TxSearchResult
+
+	// searchedAll is true if the results are from the complete transaction
+	// index. The search is repeated once the index is ready otherwise.
+	searchedAll bool
+
+	backButton      decredmaterial.IconButton
+	walletDropDown  *decredmaterial.DropDown
+	accountDropDown *decredmaterial.DropDown
+	accounts        []int32
+
+	textEditor      decredmaterial.Editor
+	minAmountEditor decredmaterial.Editor
+	maxAmountEditor decredmaterial.Editor
+	fromEditor      decredmaterial.Editor
+	toEditor        decredmaterial.Editor
+
+	container     layout.List
+	resultButtons []*decredmaterial.Clickable
+}
+
+func NewTxSearchPage(l *load.Load) *TxSearchPage {
+	newEditor := func(hint string) decredmaterial.Editor {
+		editor := l.Theme.Editor(new(widget.Editor), hint)
+		editor.Editor.SingleLine, editor.Editor.Submit = true, true
+		return editor
+	}
+
+	pg := &TxSearchPage{
+		Load:            l,
+		textEditor:      newEditor("Transaction hash, address or label"),
+		minAmountEditor: newEditor("Minimum amount (DCR)"),
+		maxAmountEditor: newEditor("Maximum amount (DCR)"),
+		fromEditor:      newEditor("From (YYYY-MM-DD)"),
+		toEditor:        newEditor("To (YYYY-MM-DD)"),
+		container:       layout.List{Axis: layout.Vertical},
+	}
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+func (pg *TxSearchPage) ID() string {
+	return TxSearchPageID
+}
+
+func (pg *TxSearchPage) OnResume() {
+	pg.wallets = pg.WL.SortedWalletList()
+
+	items := []decredmaterial.DropDownItem{{Text: values.String(values.StrAllWallets)}}
+	for _, w := range pg.wallets {
+		items = append(items, decredmaterial.DropDownItem{Text: w.Name})
+	}
+	pg.walletDropDown = pg.Theme.DropDown(items, 1)
+	pg.updateAccountDropDown()
+
+	pg.textEditor.Editor.Focus()
+	pg.search()
+}
+
+// selectedWallet returns the wallet selected in the wallet dropdown, or nil
+// if all wallets are selected.
+func (pg *TxSearchPage) selectedWallet() *dcrlibwallet.Wallet {
+	if index := pg.walletDropDown.SelectedIndex(); index > 0 {
+		return pg.wallets[index-1]
+	}
+	return nil
+}
+
+// updateAccountDropDown lists the accounts of the selected wallet. Accounts
+// can only be picked once a wallet is selected.
+func (pg *TxSearchPage) updateAccountDropDown() {
+	items := []decredmaterial.DropDownItem{{Text: "All accounts"}}
+	pg.accounts = nil
+
+	if w := pg.selectedWallet(); w != nil {
+		accounts, err := w.GetAccountsRaw()
+		if err == nil {
+			for _, account := range accounts.Acc {
+				pg.accounts = append(pg.accounts, account.Number)
+				items = append(items, decredmaterial.DropDownItem{Text: account.Name})
+			}
+		}
+	}
+	pg.accountDropDown = pg.Theme.DropDown(items, 1)
+}
+
+// query reads the search from the editors and dropdowns. Editors with an
+// invalid value are marked and left out of the search.
+func (pg *TxSearchPage) query() wallet.TxSearch {
+	query := wallet.TxSearch{
+		Text:    pg.textEditor.Editor.Text(),
+		Account: wallet.AnyAccount,
+	}
+	if w := pg.selectedWallet(); w != nil {
+		query.WalletID = w.ID
+	}
+	if index := pg.accountDropDown.SelectedIndex(); index > 0 {
+		query.Account = pg.accounts[index-1]
+	}
+
+	parseAmount := func(editor *decredmaterial.Editor) int64 {
+		editor.ClearError()
+		text := strings.TrimSpace(editor.Editor.Text())
+		if text == "" {
+			return 0
+		}
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil || value < 0 {
+			editor.SetError("Invalid amount")
+			return 0
+		}
+		amount, err := dcrutil.NewAmount(value)
+		if err != nil {
+			editor.SetError(err.Error())
+			return 0
+		}
+		return int64(amount)
+	}
+	query.MinAmount = parseAmount(&pg.minAmountEditor)
+	query.MaxAmount = parseAmount(&pg.maxAmountEditor)
+
+	parseDate := func(editor *decredmaterial.Editor) time.Time {
+		editor.ClearError()
+		text := strings.TrimSpace(editor.Editor.Text())
+		if text == "" {
+			return time.Time{}
+		}
+		date, err := time.ParseInLocation(txSearchDateFormat, text, time.Local)
+		if err != nil {
+			editor.SetError("Use the format YYYY-MM-DD")
+			return time.Time{}
+		}
+		return date
+	}
+	query.From = parseDate(&pg.fromEditor)
+	if to := parseDate(&pg.toEditor); !to.IsZero() {
+		// include the whole day
+		query.To = to.AddDate(0, 0, 1).Add(-time.Second)
+	}
+
+	return query
+}
+
+func (pg *TxSearchPage) search() {
+	query := pg.query()
+	pg.searchedAll = pg.WL.Wallet.TxIndexReady()
+	pg.results = pg.WL.Wallet.SearchTransactions(query, maxTxSearchResults)
+
+	for len(pg.resultButtons) < len(pg.results) {
+		button := pg.Theme.NewClickable(true)
+		button.Radius = decredmaterial.Radius(values.MarginPadding14.V)
+		pg.resultButtons = append(pg.resultButtons, button)
+	}
+}
+
+func (pg *TxSearchPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      "Search transactions",
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.PopFragment()
+			},
+			Body: func(gtx C) D {
+				return layout.Stack{}.Layout(gtx,
+					layout.Expanded(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, pg.layoutBody)
+					}),
+					layout.Expanded(func(gtx C) D {
+						return pg.accountDropDown.Layout(gtx, pg.walletDropDown.Width, false)
+					}),
+					layout.Expanded(func(gtx C) D {
+						return pg.walletDropDown.Layout(gtx, 0, false)
+					}),
+				)
+			},
+		}
+		return page.Layout(gtx)
+	}
+
+	return components.UniformPadding(gtx, body)
+}
+
+// layoutBody lays out the filters, the search status and a row for each
+// result in one list.
+func (pg *TxSearchPage) layoutBody(gtx C) D {
+	sections := []layout.Widget{
+		pg.layoutFilters,
+		pg.layoutStatus,
+	}
+	if len(pg.results) == 0 {
+		sections = append(sections, pg.layoutNoResults)
+	}
+	for i := range pg.results {
+		index := i
+		sections = append(sections, func(gtx C) D {
+			return pg.layoutResult(gtx, index)
+		})
+	}
+
+	return pg.container.Layout(gtx, len(sections), func(gtx C, i int) D {
+		return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, sections[i])
+	})
+}
+
+func (pg *TxSearchPage) layoutFilters(gtx C) D {
+	editor := func(editor decredmaterial.Editor) layout.Widget {
+		return func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding15}.Layout(gtx, editor.Layout)
+		}
+	}
+	pair := func(left, right decredmaterial.Editor) layout.Widget {
+		return func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Flexed(1, editor(left)),
+				layout.Rigid(layout.Spacer{Width: values.MarginPadding15}.Layout),
+				layout.Flexed(1, editor(right)),
+			)
+		}
+	}
+
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(editor(pg.textEditor)),
+				layout.Rigid(pair(pg.minAmountEditor, pg.maxAmountEditor)),
+				layout.Rigid(pair(pg.fromEditor, pg.toEditor)),
+			)
+		})
+	})
+}
+
+func (pg *TxSearchPage) layoutStatus(gtx C) D {
+	status := fmt.Sprintf("%d of %d indexed transactions", len(pg.results), pg.WL.Wallet.IndexedTransactions())
+	if len(pg.results) == maxTxSearchResults {
+		status = fmt.Sprintf("Showing the newest %d matches", maxTxSearchResults)
+	}
+	if !pg.WL.Wallet.TxIndexReady() {
+		status = "Indexing transactions..."
+	}
+
+	txt := pg.Theme.Caption(status)
+	txt.Color = pg.Theme.Color.Gray
+	return txt.Layout(gtx)
+}
+
+func (pg *TxSearchPage) layoutNoResults(gtx C) D {
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		txt := pg.Theme.Body1("No matching transactions")
+		txt.Color = pg.Theme.Color.Gray2
+		return layout.Center.Layout(gtx, func(gtx C) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, txt.Layout)
+		})
+	})
+}
+
+func (pg *TxSearchPage) layoutResult(gtx C, index int) D {
+	result := pg.results[index]
+	var walletName string
+	if w := pg.WL.MultiWallet.WalletWithID(result.WalletID); w != nil {
+		walletName = w.Name
+	}
+
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		return pg.resultButtons[index].Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						hash := pg.Theme.Body1(components.TruncateString(result.Hash, 27))
+						amount := pg.Theme.Body1(dcrutil.Amount(result.Amount).String())
+						return components.EndToEndRow(gtx, hash.Layout, amount.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						details := fmt.Sprintf("%s, %s %s", walletName, result.Type, wallet.TxDirectionName(result.Direction))
+						left := pg.Theme.Caption(details)
+						left.Color = pg.Theme.Color.Gray
+						right := pg.Theme.Caption(time.Unix(result.Timestamp, 0).Format("2006-01-02 15:04"))
+						right.Color = pg.Theme.Color.Gray
+						return components.EndToEndRow(gtx, left.Layout, right.Layout)
+					}),
+				)
+			})
+		})
+	})
+}
+
+func (pg *TxSearchPage) Handle() {
+	for pg.walletDropDown.Changed() {
+		pg.updateAccountDropDown()
+		pg.search()
+	}
+
+	for pg.accountDropDown.Changed() {
+		pg.search()
+	}
+
+	_, changed := decredmaterial.HandleEditorEvents(pg.textEditor.Editor, pg.minAmountEditor.Editor,
+		pg.maxAmountEditor.Editor, pg.fromEditor.Editor, pg.toEditor.Editor)
+	if changed || (!pg.searchedAll && pg.WL.Wallet.TxIndexReady()) {
+		pg.search()
+	}
+
+	for i, result := range pg.results {
+		for pg.resultButtons[i].Clicked() {
+			pg.openResult(result)
+		}
+	}
+}
+
+// openResult shows the details of a search result.
+func (pg *TxSearchPage) openResult(result wallet.TxSearchResult) {
+	w := pg.WL.MultiWallet.WalletWithID(result.WalletID)
+	if w == nil {
+		return
+	}
+	tx, err := w.GetTransactionRaw(result.Hash)
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	pg.ChangeFragment(NewTransactionDetailsPage(pg.Load, tx))
+}
+
+func (pg *TxSearchPage) OnClose() {}
@@ -19,6 +19,7 @@ type WalletSettingsPage struct {
 	wallet *dcrlibwallet.Wallet
 
 	changePass, rescan, deleteWallet *decredmaterial.Clickable
+	exportLabels, importLabels       *decredmaterial.Clickable
 
 	chevronRightIcon *widget.Icon
 	backButton       decredmaterial.IconButton
@@ -31,6 +32,8 @@ func NewWalletSettingsPage(l *load.Load, wal *dcrlibwallet.Wallet) *WalletSettin
 		changePass:   l.Theme.NewClickable(false),
 		rescan:       l.Theme.NewClickable(false),
 		deleteWallet: l.Theme.NewClickable(false),
+		exportLabels: l.Theme.NewClickable(false),
+		importLabels: l.Theme.NewClickable(false),
 
 		chevronRightIcon: l.Icons.ChevronRight,
 	}
@@ -38,6 +41,8 @@ func NewWalletSettingsPage(l *load.Load, wal *dcrlibwallet.Wallet) *WalletSettin
 	pg.changePass.Radius = decredmaterial.Radius(14)
 	pg.rescan.Radius = decredmaterial.Radius(14)
 	pg.deleteWallet.Radius = decredmaterial.Radius(14)
+	pg.exportLabels.Radius = decredmaterial.Radius(14)
+	pg.importLabels.Radius = decredmaterial.Radius(14)
 
 	pg.chevronRightIcon.Color = l.Theme.Color.LightGray
 	pg.backButton, _ = components.SubpageHeaderButtons(l)
@@ -72,6 +77,7 @@ func (pg *WalletSettingsPage) Layout(gtx layout.Context) layout.Dimensions {
 						}
 						return layout.Dimensions{}
 					}),
+					layout.Rigid(pg.labels()),
 					layout.Rigid(pg.debug()),
 					layout.Rigid(pg.dangerZone()),
 				)
@@ -97,6 +103,34 @@ func (pg *WalletSettingsPage) changePassphrase() layout.Widget {
 	}
 }
 
+func (pg *WalletSettingsPage) labels() layout.Widget {
+	row := func(clickable *decredmaterial.Clickable, title string) layout.Widget {
+		return func(gtx C) D {
+			return clickable.Layout(gtx, func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+						layout.Rigid(pg.bottomSectionLabel(title)),
+						layout.Flexed(1, func(gtx C) D {
+							return layout.E.Layout(gtx, func(gtx C) D {
+								return pg.chevronRightIcon.Layout(gtx, values.MarginPadding20)
+							})
+						}),
+					)
+				})
+			})
+		}
+	}
+
+	return func(gtx C) D {
+		return pg.pageSections(gtx, "Labels", nil, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(row(pg.exportLabels, "Export labels")),
+				layout.Rigid(row(pg.importLabels, "Import labels")),
+			)
+		})
+	}
+}
+
 func (pg *WalletSettingsPage) debug() layout.Widget {
 	return func(gtx C) D {
 		return pg.pageSections(gtx, values.String(values.StrDebug), pg.rescan, func(gtx C) D {
@@ -203,6 +237,14 @@ func (pg *WalletSettingsPage) Handle() {
 		break
 	}
 
+	for pg.exportLabels.Clicked() {
+		newLabelsFileModal(pg.Load, pg.wallet, false).Show()
+	}
+
+	for pg.importLabels.Clicked() {
+		newLabelsFileModal(pg.Load, pg.wallet, true).Show()
+	}
+
 	for pg.rescan.Clicked() {
 		go func() {
 			info := modal.NewInfoModal(pg.Load).
@@ -24,7 +24,6 @@ func (win *Window) updateSyncProgress(report interface{}) {
 		status.Steps = wallet.FetchHeadersSteps
 		status.CurrentBlockHeight = t.Progress.CurrentHeaderHeight
 		win.wallet.OverallBlockHeight = t.Progress.TotalHeadersToFetch
-		win.wallet.GetMultiWalletInfo()
 	case wallet.SyncAddressDiscoveryProgress:
 		status.RescanHeadersProgress = t.Progress.AddressDiscoveryProgress
 		status.Progress = t.Progress.TotalSyncProgress
@@ -38,13 +37,6 @@ func (win *Window) updateSyncProgress(report interface{}) {
 		status.TotalSteps = wallet.TotalSyncSteps
 		status.Steps = wallet.RescanHeadersStep
 	case wallet.NewBlock:
-		for _, info := range win.walletInfo.Wallets {
-			if info.ID == t.WalletID {
-				win.wallet.GetAllTransactions(0, 0, 0)
-				break
-			}
-		}
-
 		beep := win.wallet.ReadBoolConfigValueForKey(dcrlibwallet.BeepNewBlocksConfigKey)
 		if beep {
 			err := beeep.Beep(5, 1)
@@ -52,14 +44,6 @@ func (win *Window) updateSyncProgress(report interface{}) {
 				log.Error(err.Error())
 			}
 		}
-	case wallet.TxConfirmed:
-		if t.Hash != "" {
-			win.wallet.GetAllTransactions(0, 0, 0)
-			if win.walletTransaction != nil &&
-				win.walletTransaction.Txn.Hash == t.Hash {
-				win.wallet.GetTransaction(t.WalletID, t.Hash)
-			}
-		}
 	}
 }
 
@@ -12,4 +12,10 @@ func init() {
 	ArrLanguages[localizable.ENGLISH] = StrEnglish
 	ArrLanguages[localizable.FRENCH] = StrFrench
 	ArrLanguages[localizable.SPANISH] = StrSpanish
+
+	ArrExchangeCurrencies = make(map[string]string)
+	ArrExchangeCurrencies["USD"] = StrUSD
+	ArrExchangeCurrencies["EUR"] = StrEUR
+	ArrExchangeCurrencies["GBP"] = StrGBP
+	ArrExchangeCurrencies["BRL"] = StrBRL
 }
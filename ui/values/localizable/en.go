@@ -54,7 +54,7 @@ const EN = `
 "transactionId" = "Transaction ID";
 "xInputsConsumed" = "%d Inputs consumed";
 "xOutputCreated" = "%d Outputs created";
-"viewOnDcrdata" = "View on dcrdata";
+"viewOnDcrdata" = "View on block explorer";
 "watchOnlyWallets" = "Watch-only wallets";
 "signMessage" = "Sign message";
 "verifyMessage" = "Verify message";
@@ -115,7 +115,15 @@ const EN = `
 "english" = "English";
 "french" = "French";
 "spanish" = "Spanish";
-"usdBittrex" = "USD (Bittrex)";
+"usd" = "US Dollar (USD)";
+"eur" = "Euro (EUR)";
+"gbp" = "British Pound (GBP)";
+"brl" = "Brazilian Real (BRL)";
 "none" = "None";
 "proposals" = "Proposals";
+"export" = "Export";
+"exportTransactions" = "Export transactions";
+"allWallets" = "All wallets";
+"exportFile" = "Export file";
+"transactionsExported" = "%d transactions exported to %s";
 `
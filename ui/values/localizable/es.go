@@ -52,7 +52,7 @@ const ES = `
 "transactionId" = "ID de la transacción";
 "xInputsConsumed" = "%d Entradas consumidas";
 "xOutputCreated" = "%d Salidas creadas";
-"viewOnDcrdata" = "Ver en dcrdata";
+"viewOnDcrdata" = "Ver en el explorador de bloques";
 "watchOnlyWallets" = "Carteras de solo mirar";
 "signMessage" = "Firmar mensaje";
 "verifyMessage" = "Verificar mensaje";
@@ -113,7 +113,10 @@ const ES = `
 "english" = "Inglés";
 "french" = "Francés";
 "spanish" = "Español";
-"usdBittrex" = "USD (Bittrex)";
+"usd" = "Dólar estadounidense (USD)";
+"eur" = "Euro (EUR)";
+"gbp" = "Libra esterlina (GBP)";
+"brl" = "Real brasileño (BRL)";
 "none" = "Ninguno";
 "proposals" = "Propuestas";
 `
@@ -81,7 +81,7 @@ const FR = `
 "removeWallet" = "Supprimer le porte-monnaie de cet appareil";
 "recentTransactions" = "Transactions récentes";
 "ago" = "avant";
-"viewOnDcrdata" = "Voir sur dcrdata";
+"viewOnDcrdata" = "Voir sur l'explorateur de blocs";
 "beepForNewBlocks" = "Beep pour les nouveaux blocs";
 "connectToSpecificPeer" = "Se connecter à un pair spécifique";
 "english" = "Anglais";
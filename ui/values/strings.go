@@ -225,7 +225,15 @@ const (
 	StrEnglish                     = "english"
 	StrFrench                      = "french"
 	StrSpanish                     = "spanish"
-	StrUsdBittrex                  = "usdBittrex"
+	StrUSD                         = "usd"
+	StrEUR                         = "eur"
+	StrGBP                         = "gbp"
+	StrBRL                         = "brl"
 	StrNone                        = "none"
 	StrProposal                    = "proposals"
+	StrExport                      = "export"
+	StrExportTransactions          = "exportTransactions"
+	StrAllWallets                  = "allWallets"
+	StrExportFile                  = "exportFile"
+	StrTransactionsExported        = "transactionsExported"
 )
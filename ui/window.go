@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -28,12 +29,10 @@ type Window struct {
 	walletInfo           *wallet.MultiWalletInfo
 	walletSyncStatus     *wallet.SyncStatus
 	walletTransactions   *wallet.Transactions
-	walletTransaction    *wallet.Transaction
 	walletAccount        *wallet.Account
 	vspInfo              *wallet.VSP
 	proposals            *wallet.Proposals
 	selectedProposal     *dcrlibwallet.Proposal
-	proposal             chan *wallet.Proposal
 	walletUnspentOutputs *wallet.UnspentOutputs
 
 	load *load.Load
@@ -44,21 +43,17 @@ type Window struct {
 	currentPage   load.Page
 	pageBackStack []load.Page
 
-	signatureResult *wallet.Signature
-
 	selectedAccount int
 	txAuthor        dcrlibwallet.TxAuthor
 	broadcastResult wallet.Broadcast
 
 	selected int
-	states   states
-
-	err string
 
-	keyEvents             chan *key.Event
-	sysDestroyWithSync    bool
-	walletAcctMixerStatus chan *wallet.AccountMixer
-	internalLog           chan string
+	loadErr            error // error loading the multiwallet
+	keyEvents          chan *key.Event
+	syncUpdates        chan wallet.SyncStatusUpdate
+	sysDestroyWithSync bool
+	internalLog        chan string
 }
 
 type (
@@ -70,18 +65,14 @@ type WriteClipboard struct {
 }
 
 // CreateWindow creates and initializes a new window with start
-// as the first page displayed.
+// as the first page displayed. loadErr is the error loading the
+// multiwallet of wal, if any, which is shown instead of the start page.
 // Should never be called more than once as it calls
 // app.NewWindow() which does not support being called more
 // than once.
-func CreateWindow(wal *wallet.Wallet) (*Window, *app.Window, error) {
+func CreateWindow(wal *wallet.Wallet, loadErr error) (*Window, *app.Window, error) {
 	win := new(Window)
-	var netType string
-	if wal.Net == "testnet3" {
-		netType = "testnet"
-	} else {
-		netType = wal.Net
-	}
+	netType := strings.ToLower(wal.NetworkParams().DisplayName)
 	appWindow := app.NewWindow(app.Size(values.AppWidth, values.AppHeight), app.Title(values.StringF(values.StrAppTitle, netType)))
 	win.ops = &op.Ops{}
 
@@ -89,14 +80,18 @@ func CreateWindow(wal *wallet.Wallet) (*Window, *app.Window, error) {
 	win.walletSyncStatus = new(wallet.SyncStatus)
 	win.walletTransactions = new(wallet.Transactions)
 	win.walletUnspentOutputs = new(wallet.UnspentOutputs)
-	win.walletAcctMixerStatus = make(chan *wallet.AccountMixer)
 	win.vspInfo = new(wallet.VSP)
 	win.proposals = new(wallet.Proposals)
-	win.proposal = make(chan *wallet.Proposal)
 	win.invalidate = make(chan struct{}, 2)
 
 	win.wallet = wal
-	win.states.loading = false
+	win.loadErr = loadErr
+	win.syncUpdates = make(chan wallet.SyncStatusUpdate, 2)
+	wal.Events().Subscribe(wallet.EventHandlers{
+		OnSync: func(update wallet.SyncStatusUpdate) {
+			win.syncUpdates <- update
+		},
+	})
 
 	win.keyEvents = make(chan *key.Event)
 
@@ -133,11 +128,8 @@ func (win *Window) NewLoad() (*load.Load, error) {
 	}
 
 	l.Receiver = &load.Receiver{
-		KeyEvents:           win.keyEvents,
-		AcctMixerStatus:     win.walletAcctMixerStatus,
-		InternalLog:         win.internalLog,
-		SyncedProposal:      win.proposal,
-		NotificationsUpdate: make(chan interface{}, 10),
+		KeyEvents:   win.keyEvents,
+		InternalLog: win.internalLog,
 	}
 
 	l.SelectedWallet = &win.selected
@@ -150,12 +142,18 @@ func (win *Window) NewLoad() (*load.Load, error) {
 	return l, nil
 }
 
-func (win *Window) Start() {
+// Start shows the start page the first time the window runs. It returns
+// the error loading the multiwallet, if any.
+func (win *Window) Start() error {
+	if win.loadErr != nil {
+		return win.loadErr
+	}
 	if win.currentPage == nil {
 		sp := page.NewStartPage(win.load)
 		sp.OnResume()
 		win.currentPage = sp
 	}
+	return nil
 }
 
 func (win *Window) changePage(page load.Page, keepBackStack bool) {
@@ -211,8 +209,12 @@ func (win *Window) dismissModal(modal load.Modal) {
 	}
 }
 
-func (win *Window) unloaded(w *app.Window) {
-	lbl := win.load.Theme.H3("Multiwallet not loaded\nIs another instance open?")
+func (win *Window) unloaded(w *app.Window, err error) {
+	text := "Multiwallet not loaded\n" + err.Error()
+	if err.Error() == dcrlibwallet.ErrWalletDatabaseInUse {
+		text = "Multiwallet not loaded\nIs another instance open?"
+	}
+	lbl := win.load.Theme.H3(text)
 	for {
 		e := <-w.Events()
 		switch evt := e.(type) {
@@ -268,28 +270,7 @@ func (win *Window) Loop(w *app.Window, shutdown chan int) {
 		select {
 		case <-win.invalidate:
 			w.Invalidate()
-		case e := <-win.wallet.Send:
-			if e.Err != nil {
-				err := e.Err.Error()
-				log.Error("Wallet Error: " + err)
-				if err == dcrlibwallet.ErrWalletDatabaseInUse {
-					close(shutdown)
-					win.unloaded(w)
-					return
-				}
-				win.err = err
-				if win.states.loading {
-					log.Warn("Attemping to get multiwallet info")
-					win.wallet.GetMultiWalletInfo()
-				}
-
-				op.InvalidateOp{}.Add(win.ops)
-				break
-			}
-
-			// win.updateStates(e.Resp)
-
-		case update := <-win.wallet.Sync:
+		case update := <-win.syncUpdates:
 			switch update.Stage {
 			case wallet.SyncCompleted:
 				if win.sysDestroyWithSync {
@@ -319,27 +300,21 @@ func (win *Window) Loop(w *app.Window, shutdown chan int) {
 				win.updateConnectedPeers(update.ConnectedPeers)
 			case wallet.BlockAttached:
 				if win.walletInfo.Synced {
-					win.wallet.GetMultiWalletInfo()
 					win.updateSyncProgress(update.BlockInfo)
 				}
-			case wallet.BlockConfirmed:
-				win.updateSyncProgress(update.ConfirmedTxn)
-			case wallet.AccountMixerStarted, wallet.AccountMixerEnded:
-				go func() {
-					win.walletAcctMixerStatus <- &update.AcctMixerInfo
-				}()
-			case wallet.ProposalAdded, wallet.ProposalVoteFinished, wallet.ProposalVoteStarted, wallet.ProposalSynced:
-				win.wallet.GetAllProposals()
-				go func() {
-					win.proposal <- &update.Proposal
-				}()
 			}
 			op.InvalidateOp{}.Add(win.ops)
 		case e := <-w.Events():
 			switch evt := e.(type) {
 			case system.StageEvent:
 				if evt.Stage == system.StageRunning {
-					win.Start()
+					if err := win.Start(); err != nil {
+						log.Error("Wallet Error: " + err.Error())
+						// show the error until the window is closed
+						win.unloaded(w, err)
+						close(shutdown)
+						return
+					}
 				}
 
 			case system.DestroyEvent:
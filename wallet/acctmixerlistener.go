@@ -14,21 +14,21 @@ type AccountMixer struct {
 }
 
 func (l *listener) OnAccountMixerStarted(walletID int) {
-	l.Send <- SyncStatusUpdate{
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage: AccountMixerStarted,
 		AcctMixerInfo: AccountMixer{
 			WalletID:  walletID,
 			RunStatus: MixerStarted,
 		},
-	}
+	})
 }
 
 func (l *listener) OnAccountMixerEnded(walletID int) {
-	l.Send <- SyncStatusUpdate{
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage: AccountMixerEnded,
 		AcctMixerInfo: AccountMixer{
 			WalletID:  walletID,
 			RunStatus: MixerEnded,
 		},
-	}
+	})
 }
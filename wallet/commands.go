@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -21,104 +23,121 @@ func transactionStatus(bestBlockHeight, txnBlockHeight int32) (string, int32) {
 	return "pending", confirmations
 }
 
-// GetAllTransactions collects a per-wallet slice of transactions fitting the parameters.
-// It is non-blocking and sends its result or any error to wal.Send.
-func (wal *Wallet) GetAllTransactions(offset, limit, txfilter int32) {
-	go func() {
-		var resp Response
-		wallets, err := wal.wallets()
-		if err != nil {
-			resp.Err = err
-			wal.Send <- resp
-			return
-		}
+// AllTransactions returns a per-wallet slice of transactions fitting the
+// parameters.
+func (wal *Wallet) AllTransactions(offset, limit, txfilter int32) (*Transactions, error) {
+	wallets, err := wal.wallets()
+	if err != nil {
+		return nil, err
+	}
 
-		var recentTxs []Transaction
+	var recentTxs []Transaction
 
-		transactions := make(map[int][]Transaction)
-		ticketTxs := make(map[int][]Transaction)
-		bestBestBlock := wal.multi.GetBestBlock()
-		totalTxn := 0
+	transactions := make(map[int][]Transaction)
+	ticketTxs := make(map[int][]Transaction)
+	bestBestBlock := wal.multi.GetBestBlock()
+	totalTxn := 0
 
-		for _, wall := range wallets {
-			txs, err := wall.GetTransactionsRaw(offset, limit, txfilter, true)
-			if err != nil {
-				resp.Err = err
-				wal.Send <- resp
-				return
+	for _, wall := range wallets {
+		txs, err := wall.GetTransactionsRaw(offset, limit, txfilter, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, txnRaw := range txs {
+			totalTxn++
+			status, confirmations := transactionStatus(bestBestBlock.Height, txnRaw.BlockHeight)
+			txn := Transaction{
+				Txn:           txnRaw,
+				Status:        status,
+				Balance:       dcrutil.Amount(txnRaw.Amount).String(),
+				WalletName:    wall.Name,
+				Confirmations: confirmations,
+				DateTime:      dcrlibwallet.ExtractDateOrTime(txnRaw.Timestamp),
 			}
-			for _, txnRaw := range txs {
-				totalTxn++
-				status, confirmations := transactionStatus(bestBestBlock.Height, txnRaw.BlockHeight)
-				txn := Transaction{
-					Txn:           txnRaw,
-					Status:        status,
-					Balance:       dcrutil.Amount(txnRaw.Amount).String(),
-					WalletName:    wall.Name,
-					Confirmations: confirmations,
-					DateTime:      dcrlibwallet.ExtractDateOrTime(txnRaw.Timestamp),
-				}
-				recentTxs = append(recentTxs, txn)
-				if txn.Txn.Type == dcrlibwallet.TxTypeTicketPurchase {
-					ticketTxs[wall.ID] = append(ticketTxs[wall.ID], txn)
-				}
-				transactions[txnRaw.WalletID] = append(transactions[txnRaw.WalletID], txn)
+			recentTxs = append(recentTxs, txn)
+			if txn.Txn.Type == dcrlibwallet.TxTypeTicketPurchase {
+				ticketTxs[wall.ID] = append(ticketTxs[wall.ID], txn)
 			}
+			transactions[txnRaw.WalletID] = append(transactions[txnRaw.WalletID], txn)
 		}
+	}
 
-		sort.SliceStable(recentTxs, func(i, j int) bool {
-			backTime := time.Unix(recentTxs[j].Txn.Timestamp, 0)
-			frontTime := time.Unix(recentTxs[i].Txn.Timestamp, 0)
-			return backTime.Before(frontTime)
-		})
+	sort.SliceStable(recentTxs, func(i, j int) bool {
+		backTime := time.Unix(recentTxs[j].Txn.Timestamp, 0)
+		frontTime := time.Unix(recentTxs[i].Txn.Timestamp, 0)
+		return backTime.Before(frontTime)
+	})
 
-		recentTxsLimit := 5
-		if len(recentTxs) > recentTxsLimit {
-			recentTxs = recentTxs[:recentTxsLimit]
-		}
+	recentTxsLimit := 5
+	if len(recentTxs) > recentTxsLimit {
+		recentTxs = recentTxs[:recentTxsLimit]
+	}
 
-		resp.Resp = &Transactions{
-			Total:   totalTxn,
-			Txs:     transactions,
-			Recent:  recentTxs,
-			Tickets: ticketTxs,
-		}
-		wal.Send <- resp
-	}()
+	return &Transactions{
+		Total:   totalTxn,
+		Txs:     transactions,
+		Recent:  recentTxs,
+		Tickets: ticketTxs,
+	}, nil
 }
 
-// GetTransaction get transaction information by wallet ID and transaction hash
-// It is non-blocking and sends its result or any error to wal.Send.
-func (wal *Wallet) GetTransaction(walletID int, txnHash string) {
-	go func() {
-		var resp Response
-		wall := wal.multi.WalletWithID(walletID)
+// AccountUnspentOutputs returns the unspent outputs of an account.
+func (wal *Wallet) AccountUnspentOutputs(walletID int, account int32) (*UnspentOutputs, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return nil, ErrIDNotExist
+	}
+
+	utxos, err := wall.UnspentOutputs(account)
+	if err != nil {
+		return nil, err
+	}
 
-		txn, err := wall.GetTransactionRaw(txnHash)
-		if err != nil {
-			resp.Err = err
-			wal.Send <- resp
-			return
+	list := make([]*UnspentOutput, len(utxos))
+	for i, utxo := range utxos {
+		list[i] = &UnspentOutput{
+			UTXO:     *utxo,
+			Amount:   dcrutil.Amount(utxo.Amount).String(),
+			DateTime: dcrlibwallet.ExtractDateOrTime(utxo.ReceiveTime),
 		}
-		bestBestBlock := wal.multi.GetBestBlock()
-		status, confirmations := transactionStatus(bestBestBlock.Height, txn.BlockHeight)
-		acct, err := wall.GetAccount(txn.Inputs[0].AccountNumber)
-		if err != nil {
-			resp.Err = err
-			wal.Send <- resp
-			return
-		}
-		resp.Resp = &Transaction{
-			Txn:           *txn,
-			Status:        status,
-			Balance:       dcrutil.Amount(txn.Amount).String(),
-			WalletName:    wall.Name,
-			Confirmations: confirmations,
-			DateTime:      dcrlibwallet.ExtractDateOrTime(txn.Timestamp),
-			AccountName:   acct.Name,
-		}
-		wal.Send <- resp
-	}()
+	}
+
+	return &UnspentOutputs{List: list}, nil
+}
+
+// SendToAddress sends amount atoms from an account to address and returns
+// the hash of the transaction.
+func (wal *Wallet) SendToAddress(walletID int, account int32, address string, amount int64, passphrase []byte) (string, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return "", ErrIDNotExist
+	}
+
+	if wall.IsWatchingOnlyWallet() {
+		return "", errors.New("watch-only wallets cannot spend")
+	}
+
+	unsignedTx, err := wal.multi.NewUnsignedTx(walletID, account)
+	if err != nil {
+		return "", err
+	}
+
+	err = unsignedTx.AddSendDestination(address, amount, false)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := unsignedTx.Broadcast(passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	// transaction hashes are displayed in reverse byte order
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+
+	return hex.EncodeToString(hash), nil
 }
 
 // WalletSyncStatus returns the sync status of a single wallet
@@ -138,137 +157,108 @@ func (wal *Wallet) CancelSync() {
 	go wal.multi.CancelSync()
 }
 
-// GetMultiWalletInfo gets bulk information about the loaded wallets.
+// MultiWalletInfo returns bulk information about the loaded wallets.
 // Information regarding transactions is collected with respect to wal.confirms as the
 // number of required confirmations for said transactions.
-// It is non-blocking and sends its result or any error to wal.Send.
-func (wal *Wallet) GetMultiWalletInfo() {
-	go func() {
-		log.Debug("Getting multiwallet info")
-		var resp Response
-		wallets, err := wal.wallets()
+func (wal *Wallet) MultiWalletInfo() (MultiWalletInfo, error) {
+	wallets, err := wal.wallets()
+	if err != nil {
+		return MultiWalletInfo{}, err
+	}
+
+	var completeTotal int64
+	infos := make([]InfoShort, len(wallets))
+	i := 0
+	for _, wall := range wallets {
+		iter, err := wall.AccountsIterator()
 		if err != nil {
-			resp.Err = err
-			wal.Send <- resp
-			return
+			return MultiWalletInfo{}, err
 		}
-
-		var completeTotal int64
-		infos := make([]InfoShort, len(wallets))
-		i := 0
-		for _, wall := range wallets {
-			iter, err := wall.AccountsIterator()
-			if err != nil {
-				resp.Err = err
-				wal.Send <- resp
-				return
-			}
-			var acctBalance, spendableBalance int64
-			accts := make([]Account, 0)
-			for acct := iter.Next(); acct != nil; acct = iter.Next() {
-				var addr string
-				if acct.Number != math.MaxInt32 {
-					var er error
-					addr, er = wall.CurrentAddress(acct.Number)
-					if er != nil {
-						log.Error("Could not get current address for wallet ", wall.ID, "account", acct.Number)
-					}
+		var acctBalance, spendableBalance int64
+		accts := make([]Account, 0)
+		for acct := iter.Next(); acct != nil; acct = iter.Next() {
+			var addr string
+			if acct.Number != math.MaxInt32 {
+				var er error
+				addr, er = wall.CurrentAddress(acct.Number)
+				if er != nil {
+					log.Error("Could not get current address for wallet ", wall.ID, "account", acct.Number)
 				}
-				accts = append(accts, Account{
-					Number:           acct.Number,
-					Name:             acct.Name,
-					TotalBalance:     dcrutil.Amount(acct.TotalBalance).String(),
-					SpendableBalance: acct.Balance.Spendable,
-					Balance: Balance{
-						Total:                   acct.Balance.Total,
-						Spendable:               acct.Balance.Spendable,
-						ImmatureReward:          acct.Balance.ImmatureReward,
-						ImmatureStakeGeneration: acct.Balance.ImmatureStakeGeneration,
-						LockedByTickets:         acct.Balance.LockedByTickets,
-						VotingAuthority:         acct.Balance.VotingAuthority,
-						UnConfirmed:             acct.Balance.UnConfirmed,
-					},
-					Keys: struct {
-						Internal, External, Imported string
-					}{
-						Internal: strconv.Itoa(int(acct.InternalKeyCount)),
-						External: strconv.Itoa(int(acct.ExternalKeyCount)),
-						Imported: strconv.Itoa(int(acct.ImportedKeyCount)),
-					},
-					HDPath:         wal.hdPrefix() + strconv.Itoa(int(acct.Number)) + "'",
-					CurrentAddress: addr,
-				})
-				acctBalance += acct.TotalBalance
-				spendableBalance += acct.Balance.Spendable
-			}
-			completeTotal += acctBalance
-
-			infos[i] = InfoShort{
-				ID:               wall.ID,
-				Name:             wall.Name,
-				Balance:          dcrutil.Amount(acctBalance).String(),
-				SpendableBalance: spendableBalance,
-				Accounts:         accts,
-				BestBlockHeight:  wall.GetBestBlock(),
-				BlockTimestamp:   wall.GetBestBlockTimeStamp(),
-				DaysBehind:       fmt.Sprintf("%s behind", calculateDaysBehind(wall.GetBestBlockTimeStamp())),
-				Status:           walletSyncStatus(wall.IsWaiting(), wall.GetBestBlock(), wal.OverallBlockHeight),
-				Seed:             wall.EncryptedSeed,
-				IsWatchingOnly:   wall.IsWatchingOnlyWallet(),
 			}
-			i++
+			accts = append(accts, Account{
+				Number:           acct.Number,
+				Name:             acct.Name,
+				TotalBalance:     dcrutil.Amount(acct.TotalBalance).String(),
+				SpendableBalance: acct.Balance.Spendable,
+				Balance: Balance{
+					Total:                   acct.Balance.Total,
+					Spendable:               acct.Balance.Spendable,
+					ImmatureReward:          acct.Balance.ImmatureReward,
+					ImmatureStakeGeneration: acct.Balance.ImmatureStakeGeneration,
+					LockedByTickets:         acct.Balance.LockedByTickets,
+					VotingAuthority:         acct.Balance.VotingAuthority,
+					UnConfirmed:             acct.Balance.UnConfirmed,
+				},
+				Keys: struct {
+					Internal, External, Imported string
+				}{
+					Internal: strconv.Itoa(int(acct.InternalKeyCount)),
+					External: strconv.Itoa(int(acct.ExternalKeyCount)),
+					Imported: strconv.Itoa(int(acct.ImportedKeyCount)),
+				},
+				HDPath:         wal.hdPrefix() + strconv.Itoa(int(acct.Number)) + "'",
+				CurrentAddress: addr,
+			})
+			acctBalance += acct.TotalBalance
+			spendableBalance += acct.Balance.Spendable
 		}
+		completeTotal += acctBalance
+
+		infos[i] = InfoShort{
+			ID:               wall.ID,
+			Name:             wall.Name,
+			Balance:          dcrutil.Amount(acctBalance).String(),
+			SpendableBalance: spendableBalance,
+			Accounts:         accts,
+			BestBlockHeight:  wall.GetBestBlock(),
+			BlockTimestamp:   wall.GetBestBlockTimeStamp(),
+			DaysBehind:       fmt.Sprintf("%s behind", calculateDaysBehind(wall.GetBestBlockTimeStamp())),
+			Status:           walletSyncStatus(wall.IsWaiting(), wall.GetBestBlock(), wal.OverallBlockHeight),
+			Seed:             wall.EncryptedSeed,
+			IsWatchingOnly:   wall.IsWatchingOnlyWallet(),
+		}
+		i++
+	}
 
-		best := wal.multi.GetBestBlock()
+	best := wal.multi.GetBestBlock()
 
-		if best == nil {
-			if len(wallets) == 0 {
-				wal.Send <- ResponseResp(MultiWalletInfo{})
-				return
-			}
-			resp.Err = InternalWalletError{
-				Message: "Could not get load best block",
-			}
-			wal.Send <- resp
-			return
+	if best == nil {
+		if len(wallets) == 0 {
+			return MultiWalletInfo{}, nil
 		}
-
-		lastSyncTime := int64(time.Since(time.Unix(best.Timestamp, 0)).Seconds())
-		resp.Resp = MultiWalletInfo{
-			LoadedWallets:   len(wallets),
-			TotalBalance:    dcrutil.Amount(completeTotal).String(),
-			TotalBalanceRaw: GetRawBalance(completeTotal, 0),
-			BestBlockHeight: best.Height,
-			BestBlockTime:   best.Timestamp,
-			LastSyncTime:    SecondsToDays(lastSyncTime),
-			Wallets:         infos,
-			Synced:          wal.multi.IsSynced(),
-			Syncing:         wal.multi.IsSyncing(),
+		return MultiWalletInfo{}, InternalWalletError{
+			Message: "Could not get load best block",
 		}
-		wal.Send <- resp
-	}()
+	}
+
+	lastSyncTime := int64(time.Since(time.Unix(best.Timestamp, 0)).Seconds())
+	return MultiWalletInfo{
+		LoadedWallets:   len(wallets),
+		TotalBalance:    dcrutil.Amount(completeTotal).String(),
+		TotalBalanceRaw: GetRawBalance(completeTotal, 0),
+		BestBlockHeight: best.Height,
+		BestBlockTime:   best.Timestamp,
+		LastSyncTime:    SecondsToDays(lastSyncTime),
+		Wallets:         infos,
+		Synced:          wal.multi.IsSynced(),
+		Syncing:         wal.multi.IsSyncing(),
+	}, nil
 }
 
 func (wal *Wallet) GetMultiWallet() *dcrlibwallet.MultiWallet {
 	return wal.multi
 }
 
-func (wal *Wallet) GetAllProposals() {
-	var resp Response
-	go func() {
-		proposals, err := wal.multi.Politeia.GetProposalsRaw(dcrlibwallet.ProposalCategoryAll, 0, 0, true)
-		if err != nil {
-			resp.Err = err
-			wal.Send <- resp
-			return
-		}
-		resp.Resp = &Proposals{
-			Proposals: proposals,
-		}
-		wal.Send <- resp
-	}()
-}
-
 func (wal *Wallet) UnlockWallet(walletID int, password []byte) error {
 	return wal.multi.UnlockWallet(walletID, password)
 }
@@ -0,0 +1,171 @@
+package wallet
+
+import (
+	"sync"
+)
+
+// EventBus delivers wallet events to the subscriptions that handle them.
+// Each subscription receives its events in the order they were published,
+// on a goroutine of its own, so a slow handler never blocks the publisher
+// or other subscriptions.
+type EventBus struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[*Subscription]struct{})}
+}
+
+// EventHandlers are the handlers of a subscription. Events without a
+// handler are not delivered to it.
+type EventHandlers struct {
+	OnTransaction func(NewTransaction)
+	OnBlock       func(NewBlock)
+	OnSync        func(SyncStatusUpdate)
+	OnRescan      func(RescanUpdate)
+	OnProposal    func(Proposal)
+}
+
+// Subscription receives the events of an EventBus it has a handler for. It
+// stops receiving events once Unsubscribe is called.
+type Subscription struct {
+	bus      *EventBus
+	handlers EventHandlers
+
+	mu     sync.Mutex
+	queue  []func()
+	notify chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Subscribe returns a subscription that delivers events to handlers. The
+// handlers are set before the subscription is added, so no event published
+// after Subscribe returns is missed.
+func (b *EventBus) Subscribe(handlers EventHandlers) *Subscription {
+	s := &Subscription{
+		bus:      b,
+		handlers: handlers,
+		notify:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+
+	go s.run()
+	return s
+}
+
+func (s *Subscription) run() {
+	for {
+		select {
+		case <-s.notify:
+		case <-s.done:
+			return
+		}
+
+		s.mu.Lock()
+		queue := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+
+		for _, deliver := range queue {
+			select {
+			case <-s.done:
+				return
+			default:
+				deliver()
+			}
+		}
+	}
+}
+
+// enqueue adds the delivery of an event to the queue of the subscription.
+func (s *Subscription) enqueue(deliver func()) {
+	s.mu.Lock()
+	s.queue = append(s.queue, deliver)
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Unsubscribe stops the delivery of events. Events that have not been
+// delivered yet are dropped. It is safe to call more than once and from a
+// handler of the subscription.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		delete(s.bus.subs, s)
+		s.bus.mu.Unlock()
+		close(s.done)
+	})
+}
+
+// publish enqueues the delivery returned by deliver for every subscription.
+// deliver returns nil for subscriptions without a handler for the event.
+func (b *EventBus) publish(deliver func(s *Subscription) func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subs {
+		if d := deliver(s); d != nil {
+			s.enqueue(d)
+		}
+	}
+}
+
+func (b *EventBus) PublishTransaction(event NewTransaction) {
+	b.publish(func(s *Subscription) func() {
+		if handler := s.handlers.OnTransaction; handler != nil {
+			return func() { handler(event) }
+		}
+		return nil
+	})
+}
+
+func (b *EventBus) PublishBlock(event NewBlock) {
+	b.publish(func(s *Subscription) func() {
+		if handler := s.handlers.OnBlock; handler != nil {
+			return func() { handler(event) }
+		}
+		return nil
+	})
+}
+
+func (b *EventBus) PublishSync(event SyncStatusUpdate) {
+	b.publish(func(s *Subscription) func() {
+		if handler := s.handlers.OnSync; handler != nil {
+			return func() { handler(event) }
+		}
+		return nil
+	})
+}
+
+func (b *EventBus) PublishRescan(event RescanUpdate) {
+	b.publish(func(s *Subscription) func() {
+		if handler := s.handlers.OnRescan; handler != nil {
+			return func() { handler(event) }
+		}
+		return nil
+	})
+}
+
+func (b *EventBus) PublishProposal(event Proposal) {
+	b.publish(func(s *Subscription) func() {
+		if handler := s.handlers.OnProposal; handler != nil {
+			return func() { handler(event) }
+		}
+		return nil
+	})
+}
+
+// Events returns the bus the wallet events are published to.
+func (wal *Wallet) Events() *EventBus {
+	return wal.events
+}
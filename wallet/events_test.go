@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBusDeliversInOrder(t *testing.T) {
+	bus := NewEventBus()
+
+	blocks := make(chan int32, 100)
+	sub := bus.Subscribe(EventHandlers{
+		OnBlock: func(block NewBlock) {
+			blocks <- block.Height
+		},
+	})
+	defer sub.Unsubscribe()
+
+	// events without a handler are skipped
+	bus.PublishProposal(Proposal{})
+	for height := int32(0); height < 100; height++ {
+		bus.PublishBlock(NewBlock{Height: height})
+	}
+
+	for want := int32(0); want < 100; want++ {
+		select {
+		case got := <-blocks:
+			if got != want {
+				t.Fatalf("got block %d, want %d", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("block %d not delivered", want)
+		}
+	}
+}
+
+func TestEventBusConcurrentPublishAndUnsubscribe(t *testing.T) {
+	bus := NewEventBus()
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					bus.PublishTransaction(NewTransaction{})
+					bus.PublishSync(SyncStatusUpdate{Stage: SyncStarted})
+				}
+			}
+		}()
+	}
+
+	// subscriptions are added and removed while events are published, some
+	// of them from their own handlers.
+	var subs sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		subs.Add(1)
+		go func(i int) {
+			defer subs.Done()
+
+			received := make(chan struct{}, 1)
+			signal := func() {
+				select {
+				case received <- struct{}{}:
+				default:
+				}
+			}
+			var sub *Subscription
+			var mu sync.Mutex
+			handlers := EventHandlers{
+				OnTransaction: func(NewTransaction) { signal() },
+			}
+			if i%2 == 0 {
+				handlers.OnSync = func(SyncStatusUpdate) {
+					mu.Lock()
+					defer mu.Unlock()
+					sub.Unsubscribe()
+					signal()
+				}
+			}
+
+			mu.Lock()
+			sub = bus.Subscribe(handlers)
+			mu.Unlock()
+
+			select {
+			case <-received:
+			case <-time.After(5 * time.Second):
+				t.Errorf("subscription %d received no events", i)
+			}
+			sub.Unsubscribe()
+			sub.Unsubscribe()
+		}(i)
+	}
+	subs.Wait()
+	close(stop)
+	wg.Wait()
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	if len(bus.subs) != 0 {
+		t.Fatalf("%d subscriptions left after unsubscribing", len(bus.subs))
+	}
+}
@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ExplorerPlaceholder is replaced by the transaction hash, block height,
+// address or proposal token in a BlockExplorer URL template.
+const ExplorerPlaceholder = "{}"
+
+// BlockExplorer holds the URL templates used to link to transactions,
+// blocks, addresses and proposals on the web. An empty template disables
+// the links of that kind.
+type BlockExplorer struct {
+	Tx       string
+	Block    string
+	Address  string
+	Proposal string
+}
+
+// DefaultBlockExplorer returns the URL templates used on net when none are
+// configured. Networks without a public explorer have no links by default.
+func DefaultBlockExplorer(net string) BlockExplorer {
+	return networks[net].BlockExplorer
+}
+
+// Merge returns the templates of be with the empty ones taken from
+// fallback.
+func (be BlockExplorer) Merge(fallback BlockExplorer) BlockExplorer {
+	if be.Tx == "" {
+		be.Tx = fallback.Tx
+	}
+	if be.Block == "" {
+		be.Block = fallback.Block
+	}
+	if be.Address == "" {
+		be.Address = fallback.Address
+	}
+	if be.Proposal == "" {
+		be.Proposal = fallback.Proposal
+	}
+	return be
+}
+
+// Validate checks that every template that is set is an http(s) URL with
+// a placeholder.
+func (be BlockExplorer) Validate() error {
+	templates := []struct {
+		name, template string
+	}{
+		{"transaction", be.Tx},
+		{"block", be.Block},
+		{"address", be.Address},
+		{"proposal", be.Proposal},
+	}
+
+	for _, t := range templates {
+		if t.template == "" {
+			continue
+		}
+
+		if !strings.Contains(t.template, ExplorerPlaceholder) {
+			return fmt.Errorf("%s explorer URL %q has no %s placeholder", t.name, t.template, ExplorerPlaceholder)
+		}
+
+		u, err := url.Parse(strings.Replace(t.template, ExplorerPlaceholder, "x", -1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s explorer URL %q is not a valid http(s) URL", t.name, t.template)
+		}
+	}
+
+	return nil
+}
+
+// expandExplorerURL replaces the placeholder of template with value. An
+// empty string is returned if the template is not set.
+func expandExplorerURL(template, value string) string {
+	if template == "" {
+		return ""
+	}
+	return strings.Replace(template, ExplorerPlaceholder, url.PathEscape(value), -1)
+}
+
+// SetBlockExplorer replaces the URL templates used to link to the block
+// explorer.
+func (wal *Wallet) SetBlockExplorer(explorer BlockExplorer) {
+	wal.explorer = explorer
+}
+
+// GetBlockExplorerURL accept transaction hash,
+// return the block explorer URL with respect to the network
+func (wal *Wallet) GetBlockExplorerURL(txnHash string) string {
+	return expandExplorerURL(wal.explorer.Tx, txnHash)
+}
+
+// BlockExplorerBlockURL returns the block explorer URL of the block at
+// height, or an empty string if no block explorer is configured.
+func (wal *Wallet) BlockExplorerBlockURL(height int32) string {
+	return expandExplorerURL(wal.explorer.Block, fmt.Sprintf("%d", height))
+}
+
+// BlockExplorerAddressURL returns the block explorer URL of address, or an
+// empty string if no block explorer is configured.
+func (wal *Wallet) BlockExplorerAddressURL(address string) string {
+	return expandExplorerURL(wal.explorer.Address, address)
+}
+
+// ProposalURL returns the Politeia URL of the proposal with token, or an
+// empty string if no proposal site is configured.
+func (wal *Wallet) ProposalURL(token string) string {
+	return expandExplorerURL(wal.explorer.Proposal, token)
+}
@@ -0,0 +1,270 @@
+package wallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// LabelTx labels a transaction. Its ref is the transaction hash.
+	LabelTx = "tx"
+
+	// LabelAddress labels an address. Its ref is the address.
+	LabelAddress = "addr"
+
+	// LabelOutput labels a transaction output, such as an unspent output.
+	// Its ref is the transaction hash and output index, as "hash:index".
+	LabelOutput = "output"
+
+	// labelsConfigKey is the wallet user config key the labels of the
+	// wallet are saved under.
+	labelsConfigKey = "godcr_labels"
+)
+
+// Label is a label and note attached to a transaction, address or output.
+// It is exported and imported as a line of JSON in the format of BIP 329,
+// extended with the note.
+type Label struct {
+	Type   string `json:"type"`
+	Ref    string `json:"ref"`
+	Label  string `json:"label,omitempty"`
+	Note   string `json:"note,omitempty"`
+	Origin string `json:"origin,omitempty"`
+}
+
+// IsEmpty returns true if the label has neither a label nor a note.
+func (l Label) IsEmpty() bool {
+	return l.Label == "" && l.Note == ""
+}
+
+func validLabelType(labelType string) bool {
+	return labelType == LabelTx || labelType == LabelAddress || labelType == LabelOutput
+}
+
+// OutputRef returns the ref of the output of a transaction.
+func OutputRef(hash string, index int32) string {
+	return fmt.Sprintf("%s:%d", hash, index)
+}
+
+func labelKey(labelType, ref string) string {
+	return labelType + " " + ref
+}
+
+// labelWallets reads and saves the labels of the wallets.
+type labelWallets interface {
+	hasWallet(walletID int) bool
+	readLabels(walletID int) []Label
+	saveLabels(walletID int, labels []Label)
+}
+
+// multiLabelWallets saves the labels of each wallet of a multiwallet in the
+// wallet user config.
+type multiLabelWallets struct {
+	multi *dcrlibwallet.MultiWallet
+}
+
+func (m multiLabelWallets) hasWallet(walletID int) bool {
+	return m.multi.WalletWithID(walletID) != nil
+}
+
+func (m multiLabelWallets) readLabels(walletID int) []Label {
+	var saved []Label
+	if w := m.multi.WalletWithID(walletID); w != nil {
+		// errors other than a missing key are logged by dcrlibwallet
+		_ = w.ReadUserConfigValue(labelsConfigKey, &saved)
+	}
+	return saved
+}
+
+func (m multiLabelWallets) saveLabels(walletID int, labels []Label) {
+	if w := m.multi.WalletWithID(walletID); w != nil {
+		w.SaveUserConfigValue(labelsConfigKey, labels)
+	}
+}
+
+// labelStore keeps the labels of each wallet in memory, as they are read
+// every time a transaction row is laid out.
+type labelStore struct {
+	mu      sync.Mutex
+	wallets labelWallets
+	labels  map[int]map[string]Label
+}
+
+// walletLabels returns the labels of a wallet, reading them the first time,
+// or false if there is no wallet with walletID. It must be called with the
+// lock held.
+func (s *labelStore) walletLabels(walletID int) (map[string]Label, bool) {
+	if s.wallets == nil || !s.wallets.hasWallet(walletID) {
+		return nil, false
+	}
+
+	if labels, ok := s.labels[walletID]; ok {
+		return labels, true
+	}
+
+	saved := s.wallets.readLabels(walletID)
+	labels := make(map[string]Label, len(saved))
+	for _, l := range saved {
+		labels[labelKey(l.Type, l.Ref)] = l
+	}
+	if s.labels == nil {
+		s.labels = make(map[int]map[string]Label)
+	}
+	s.labels[walletID] = labels
+	return labels, true
+}
+
+// save saves the labels of a wallet. It must be called with the lock held.
+func (s *labelStore) save(walletID int, labels map[string]Label) {
+	saved := make([]Label, 0, len(labels))
+	for _, l := range labels {
+		saved = append(saved, l)
+	}
+	sort.Slice(saved, func(i, j int) bool {
+		return labelKey(saved[i].Type, saved[i].Ref) < labelKey(saved[j].Type, saved[j].Ref)
+	})
+	s.wallets.saveLabels(walletID, saved)
+}
+
+func (s *labelStore) get(walletID int, labelType, ref string) Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels, _ := s.walletLabels(walletID)
+	return labels[labelKey(labelType, ref)]
+}
+
+func (s *labelStore) set(walletID int, labels []Label) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	walletLabels, ok := s.walletLabels(walletID)
+	if !ok {
+		return fmt.Errorf("wallet %d not found", walletID)
+	}
+
+	for _, l := range labels {
+		if !validLabelType(l.Type) {
+			return fmt.Errorf("unknown label type %q", l.Type)
+		}
+		if l.Ref == "" {
+			return fmt.Errorf("%s label without a ref", l.Type)
+		}
+
+		key := labelKey(l.Type, l.Ref)
+		l.Label, l.Note = strings.TrimSpace(l.Label), strings.TrimSpace(l.Note)
+		l.Origin = ""
+		if l.IsEmpty() {
+			delete(walletLabels, key)
+		} else {
+			walletLabels[key] = l
+		}
+	}
+
+	s.save(walletID, walletLabels)
+	return nil
+}
+
+func (s *labelStore) list(walletID int, labelType string) []Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels, _ := s.walletLabels(walletID)
+	var list []Label
+	for _, l := range labels {
+		if labelType == "" || l.Type == labelType {
+			list = append(list, l)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return labelKey(list[i].Type, list[i].Ref) < labelKey(list[j].Type, list[j].Ref)
+	})
+	return list
+}
+
+// matches returns true if the label or note of a transaction contains text,
+// which must be lower case.
+func (s *labelStore) matches(walletID int, hash, text string) bool {
+	l := s.get(walletID, LabelTx, hash)
+	return strings.Contains(strings.ToLower(l.Label), text) ||
+		strings.Contains(strings.ToLower(l.Note), text)
+}
+
+// Label returns the label of a transaction, address or output of a wallet.
+// The label is empty if there is none.
+func (wal *Wallet) Label(walletID int, labelType, ref string) Label {
+	return wal.labels.get(walletID, labelType, ref)
+}
+
+// SetLabel saves the label of a transaction, address or output of a wallet.
+// A label without a label or a note is removed.
+func (wal *Wallet) SetLabel(walletID int, label Label) error {
+	return wal.labels.set(walletID, []Label{label})
+}
+
+// Labels returns the labels of a wallet of labelType, or all of its labels
+// if labelType is empty.
+func (wal *Wallet) Labels(walletID int, labelType string) []Label {
+	return wal.labels.list(walletID, labelType)
+}
+
+// ExportLabels writes the labels of a wallet to w as JSON lines, with the
+// name of the wallet as their origin.
+func (wal *Wallet) ExportLabels(w io.Writer, walletID int) (int, error) {
+	var origin string
+	if wal.multi != nil {
+		if wallet := wal.multi.WalletWithID(walletID); wallet != nil {
+			origin = wallet.Name
+		}
+	}
+
+	labels := wal.labels.list(walletID, "")
+	enc := json.NewEncoder(w)
+	for _, l := range labels {
+		l.Origin = origin
+		if err := enc.Encode(l); err != nil {
+			return 0, err
+		}
+	}
+	return len(labels), nil
+}
+
+// ImportLabels reads labels written as JSON lines from r and saves them to
+// a wallet, replacing the labels it has for the same refs. Lines of label
+// types that are not supported, such as BIP 329 xpub labels, are skipped.
+// It returns the number of labels imported.
+func (wal *Wallet) ImportLabels(r io.Reader, walletID int) (int, error) {
+	var labels []Label
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var l Label
+		if err := json.Unmarshal([]byte(text), &l); err != nil {
+			return 0, fmt.Errorf("line %d: %v", line, err)
+		}
+		if !validLabelType(l.Type) || l.Ref == "" {
+			continue
+		}
+		labels = append(labels, l)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := wal.labels.set(walletID, labels); err != nil {
+		return 0, err
+	}
+	return len(labels), nil
+}
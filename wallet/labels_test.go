@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testLabelWallets keeps the saved labels of wallets in memory.
+type testLabelWallets map[int][]Label
+
+func (w testLabelWallets) hasWallet(walletID int) bool {
+	_, ok := w[walletID]
+	return ok
+}
+
+func (w testLabelWallets) readLabels(walletID int) []Label {
+	return w[walletID]
+}
+
+func (w testLabelWallets) saveLabels(walletID int, labels []Label) {
+	w[walletID] = labels
+}
+
+func TestLabelsRoundTrip(t *testing.T) {
+	saved := testLabelWallets{1: nil, 2: nil}
+	wal := new(Wallet)
+	wal.labels.wallets = saved
+
+	const hash = "8a1f5c3e0d7b6a4f2e9c1b0d3a5f7e9c2b4d6f8a0c1e3b5d7f9a2c4e6b8d0f1a"
+	input := strings.Join([]string{
+		`{"type":"tx","ref":"` + hash + `","label":" Rent ","note":"March","origin":"other wallet"}`,
+		``,
+		`{"type":"addr","ref":"TsfDLrRkk9ciUuwfp2b8PawwnukYD7yAjGd","label":"Savings"}`,
+		`{"type":"xpub","ref":"tpubVpQL4h","label":"not supported"}`,
+		`{"type":"output","ref":"` + hash + `:1","note":"change"}`,
+		`{"type":"tx","ref":""}`,
+		`   `,
+	}, "\n")
+
+	count, err := wal.ImportLabels(strings.NewReader(input), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("imported %d labels, want 3", count)
+	}
+
+	want := Label{Type: LabelTx, Ref: hash, Label: "Rent", Note: "March"}
+	if got := wal.Label(1, LabelTx, hash); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if len(saved[1]) != 3 {
+		t.Fatalf("saved %d labels, want 3", len(saved[1]))
+	}
+
+	var exported bytes.Buffer
+	count, err = wal.ExportLabels(&exported, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 || strings.Count(exported.String(), "\n") != 3 {
+		t.Fatalf("exported %d labels, want 3 lines:\n%s", count, exported.String())
+	}
+
+	// the exported labels import to the same labels
+	if _, err := wal.ImportLabels(&exported, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := wal.Labels(2, ""), wal.Labels(1, ""); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if got := wal.Labels(2, LabelAddress); len(got) != 1 || got[0].Label != "Savings" {
+		t.Fatalf("got address labels %+v", got)
+	}
+
+	// importing an empty label removes it
+	if _, err := wal.ImportLabels(strings.NewReader(`{"type":"tx","ref":"`+hash+`"}`), 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := wal.Label(2, LabelTx, hash); !got.IsEmpty() {
+		t.Fatalf("got %+v, want the label removed", got)
+	}
+}
+
+func TestImportLabelsErrors(t *testing.T) {
+	wal := new(Wallet)
+	wal.labels.wallets = testLabelWallets{1: nil}
+
+	_, err := wal.ImportLabels(strings.NewReader("\n{\"type\":\"tx\",\"ref\":\"a\"}\n{bad json\n"), 1)
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Fatalf("got error %v, want one for line 3", err)
+	}
+	if labels := wal.Labels(1, ""); len(labels) != 0 {
+		t.Fatalf("got %d labels imported from an invalid file", len(labels))
+	}
+
+	if _, err := wal.ImportLabels(strings.NewReader(`{"type":"tx","ref":"a","label":"b"}`), 2); err == nil {
+		t.Fatalf("expected an error importing to a missing wallet")
+	}
+}
@@ -86,8 +86,14 @@ const (
 const TotalSyncSteps = 3
 
 type (
+	// listener publishes the multiwallet notifications to the event bus
+	// and keeps the sync history, ticket buyer and transaction index up
+	// to date.
 	listener struct {
-		Send chan<- SyncStatusUpdate
+		events  *EventBus
+		history *syncHistory
+		buyer   *ticketBuyer
+		index   *txIndex
 	}
 
 	// SyncStatusUpdate represents information about the status of the multiwallet spv sync
@@ -145,61 +151,81 @@ func (l *listener) Debug(info *dcrlibwallet.DebugInfo) {
 	log.Trace(info)
 }
 
+// recordSync adds a sync event to the sync history if the listener keeps
+// one.
+func (l *listener) recordSync(stage SyncNotificationType, event SyncEvent) {
+	if l.history != nil {
+		l.history.record(stage, event)
+	}
+}
+
 func (l *listener) OnSyncStarted(restarted bool) {
-	l.Send <- SyncStatusUpdate{
+	l.recordSync(SyncStarted, SyncEvent{})
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage: SyncStarted,
-	}
+	})
 }
 
 func (l *listener) OnPeerConnectedOrDisconnected(numberOfConnectedPeers int32) {
-	l.Send <- SyncStatusUpdate{
+	l.recordSync(PeersConnected, SyncEvent{ConnectedPeers: numberOfConnectedPeers})
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage:          PeersConnected,
 		ConnectedPeers: numberOfConnectedPeers,
-	}
+	})
 }
 
 func (l *listener) OnHeadersFetchProgress(progress *dcrlibwallet.HeadersFetchProgressReport) {
-	l.Send <- SyncStatusUpdate{
+	l.recordSync(HeadersFetchProgress, SyncEvent{Height: progress.CurrentHeaderHeight})
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage: HeadersFetchProgress,
 		ProgressReport: SyncHeadersFetchProgress{
 			Progress: progress,
 		},
-	}
+	})
 }
 func (l *listener) OnAddressDiscoveryProgress(progress *dcrlibwallet.AddressDiscoveryProgressReport) {
-	l.Send <- SyncStatusUpdate{
+	l.recordSync(AddressDiscoveryProgress, SyncEvent{})
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage: AddressDiscoveryProgress,
 		ProgressReport: SyncAddressDiscoveryProgress{
 			Progress: progress,
 		},
-	}
+	})
 }
 
 func (l *listener) OnHeadersRescanProgress(progress *dcrlibwallet.HeadersRescanProgressReport) {
-	l.Send <- SyncStatusUpdate{
+	l.recordSync(HeadersRescanProgress, SyncEvent{Height: progress.CurrentRescanHeight})
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage: HeadersRescanProgress,
 		ProgressReport: SyncHeadersRescanProgress{
 			Progress: progress,
 		},
-	}
+	})
 }
 
 func (l *listener) OnSyncCompleted() {
-	l.Send <- SyncStatusUpdate{
-		Stage: SyncCompleted,
+	l.recordSync(SyncCompleted, SyncEvent{})
+	if l.index != nil {
+		// transactions found while syncing are not notified one by one
+		l.index.build()
 	}
+	l.events.PublishSync(SyncStatusUpdate{
+		Stage: SyncCompleted,
+	})
 }
 
 func (l *listener) OnSyncCanceled(willRestart bool) {
-	l.Send <- SyncStatusUpdate{
+	l.recordSync(SyncCanceled, SyncEvent{})
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage: SyncCanceled,
-	}
+	})
 	// l.Send <- SyncCanceled{
 	// 	WillRestart: willRestart,
 	// }
 }
 
 func (l *listener) OnSyncEndedWithError(err error) {
+	l.recordSync(SyncCanceled, SyncEvent{Error: err.Error()})
 	// todo: create custom sync error
 	// l.Send <- SyncEndedWithError{
 	// 	Error: err,
@@ -207,5 +233,27 @@ func (l *listener) OnSyncEndedWithError(err error) {
 }
 
 func (l *listener) OnCFiltersFetchProgress(progress *dcrlibwallet.CFiltersFetchProgressReport) {
+	l.recordSync(CfiltersFetchProgress, SyncEvent{Height: progress.CurrentCFilterHeight})
+}
+
+func (l *listener) OnBlocksRescanStarted(walletID int) {
+	l.events.PublishRescan(RescanUpdate{
+		Stage:    RescanStarted,
+		WalletID: walletID,
+	})
+}
+
+func (l *listener) OnBlocksRescanProgress(progress *dcrlibwallet.HeadersRescanProgressReport) {
+	l.events.PublishRescan(RescanUpdate{
+		Stage:          RescanProgress,
+		WalletID:       progress.WalletID,
+		ProgressReport: progress,
+	})
+}
 
+func (l *listener) OnBlocksRescanEnded(walletID int, err error) {
+	l.events.PublishRescan(RescanUpdate{
+		Stage:    RescanEnded,
+		WalletID: walletID,
+	})
 }
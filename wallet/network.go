@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"sort"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/dcrlibwallet/utils"
+)
+
+// Network names accepted by the network option.
+const (
+	Mainnet  = "mainnet"
+	Testnet3 = "testnet3"
+	Simnet   = "simnet"
+	Regnet   = "regnet"
+)
+
+// NetworkParams holds the app settings that differ between networks.
+type NetworkParams struct {
+	Name string
+
+	// DisplayName is the name of the network shown to the user.
+	DisplayName string
+
+	// HDPath is the BIP0044 path prefix of the accounts.
+	HDPath string
+
+	// ShufflePort is the port of the CoinShuffle++ server used by the
+	// account mixer. It is empty if the network has no mixing server.
+	ShufflePort string
+
+	// PoliteiaHost is the Politeia server proposals are synced from. It is
+	// empty if the network has no Politeia server.
+	PoliteiaHost string
+
+	// DefaultPeer is the peer SPV sync connects to when no peer is set in
+	// the settings. It is empty for networks synced from public peers.
+	DefaultPeer string
+
+	BlockExplorer BlockExplorer
+}
+
+// networks are the parameters of every network the app knows about.
+// simnet and regnet default to a dcrd harness running on the same machine.
+var networks = map[string]NetworkParams{
+	Mainnet: {
+		Name:         Mainnet,
+		DisplayName:  "Mainnet",
+		HDPath:       dcrlibwallet.MainnetHDPath,
+		ShufflePort:  dcrlibwallet.MainnetShufflePort,
+		PoliteiaHost: dcrlibwallet.PoliteiaMainnetHost,
+		BlockExplorer: BlockExplorer{
+			Tx:       "https://explorer.dcrdata.org/tx/{}",
+			Block:    "https://explorer.dcrdata.org/block/{}",
+			Address:  "https://explorer.dcrdata.org/address/{}",
+			Proposal: "https://proposals.decred.org/record/{}",
+		},
+	},
+	Testnet3: {
+		Name:         Testnet3,
+		DisplayName:  "Testnet",
+		HDPath:       dcrlibwallet.TestnetHDPath,
+		ShufflePort:  dcrlibwallet.TestnetShufflePort,
+		PoliteiaHost: dcrlibwallet.PoliteiaTestnetHost,
+		BlockExplorer: BlockExplorer{
+			Tx:       "https://testnet.dcrdata.org/tx/{}",
+			Block:    "https://testnet.dcrdata.org/block/{}",
+			Address:  "https://testnet.dcrdata.org/address/{}",
+			Proposal: "https://test-proposals.decred.org/record/{}",
+		},
+	},
+	Simnet: {
+		Name:        Simnet,
+		DisplayName: "Simnet",
+		HDPath:      "m / 44' / 115' / ",
+		DefaultPeer: "127.0.0.1:18555",
+	},
+	Regnet: {
+		Name:        Regnet,
+		DisplayName: "Regnet",
+		HDPath:      "m / 44' / 1' / ",
+		DefaultPeer: "127.0.0.1:18655",
+	},
+}
+
+// Network returns the parameters of the network with name.
+func Network(name string) (NetworkParams, bool) {
+	params, ok := networks[name]
+	return params, ok
+}
+
+// NetworkNames returns the names of the supported networks in alphabetical
+// order.
+func NetworkNames() []string {
+	names := make([]string, 0, len(networks))
+	for name, params := range networks {
+		if params.Supported() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Supported reports whether dcrlibwallet can open wallets on the network.
+func (params NetworkParams) Supported() bool {
+	_, err := utils.ChainParams(params.Name)
+	return err == nil
+}
+
+// NetworkParams returns the parameters of the network the app runs on.
+func (wal *Wallet) NetworkParams() NetworkParams {
+	return wal.network
+}
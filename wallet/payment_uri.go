@@ -0,0 +1,112 @@
+package wallet
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrutil/v3"
+)
+
+// PaymentURIScheme is the URI scheme of Decred payment requests.
+const PaymentURIScheme = "decred"
+
+// PaymentURI is a BIP21 style payment request of the form
+// decred:<address>?amount=<dcr>&label=<label>&message=<message>.
+type PaymentURI struct {
+	Address string
+	Amount  dcrutil.Amount
+	Label   string
+	Message string
+}
+
+// IsPaymentURI returns true if s starts with the decred: scheme.
+func IsPaymentURI(s string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(s)), PaymentURIScheme+":")
+}
+
+// ParsePaymentURI parses a decred: payment request. The address is not
+// validated against the network, callers should check it with
+// MultiWallet.IsAddressValid.
+func ParsePaymentURI(s string) (*PaymentURI, error) {
+	s = strings.TrimSpace(s)
+	if !IsPaymentURI(s) {
+		return nil, fmt.Errorf("not a %s: URI", PaymentURIScheme)
+	}
+
+	s = s[len(PaymentURIScheme)+1:]
+	s = strings.TrimPrefix(s, "//")
+
+	address, rawQuery := s, ""
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		address, rawQuery = s[:i], s[i+1:]
+	}
+
+	if address == "" {
+		return nil, fmt.Errorf("payment URI has no address")
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment URI parameters: %v", err)
+	}
+
+	uri := &PaymentURI{
+		Address: address,
+		Label:   query.Get("label"),
+		Message: query.Get("message"),
+	}
+
+	if amount := query.Get("amount"); amount != "" {
+		dcr, err := strconv.ParseFloat(amount, 64)
+		if err != nil || dcr < 0 {
+			return nil, fmt.Errorf("invalid payment URI amount %q", amount)
+		}
+
+		uri.Amount, err = dcrutil.NewAmount(dcr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payment URI amount %q: %v", amount, err)
+		}
+		if uri.Amount > dcrutil.MaxAmount {
+			return nil, fmt.Errorf("payment URI amount %q is more than the maximum amount", amount)
+		}
+	}
+
+	// required parameters that are not understood make the URI invalid
+	for key := range query {
+		if strings.HasPrefix(key, "req-") {
+			return nil, fmt.Errorf("unsupported payment URI parameter %q", key)
+		}
+	}
+
+	return uri, nil
+}
+
+// String returns the URI encoding of the payment request. Parameters with
+// zero values are left out.
+func (uri PaymentURI) String() string {
+	var params []string
+	if uri.Amount > 0 {
+		params = append(params, "amount="+strconv.FormatFloat(uri.Amount.ToCoin(), 'f', -1, 64))
+	}
+	if uri.Label != "" {
+		params = append(params, "label="+escapeURIParam(uri.Label))
+	}
+	if uri.Message != "" {
+		params = append(params, "message="+escapeURIParam(uri.Message))
+	}
+
+	s := PaymentURIScheme + ":" + uri.Address
+	if len(params) > 0 {
+		s += "?" + strings.Join(params, "&")
+	}
+
+	return s
+}
+
+// escapeURIParam escapes spaces as %20 rather than + since not every wallet
+// decodes + in URI parameters.
+func escapeURIParam(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
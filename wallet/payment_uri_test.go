@@ -0,0 +1,133 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/dcrutil/v3"
+)
+
+const testAddress = "TsfDLrRkk9ciUuwfp2b8PawwnukYD7yAjGd"
+
+func TestParsePaymentURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    PaymentURI
+		wantErr bool
+	}{{
+		name: "address only",
+		uri:  "decred:" + testAddress,
+		want: PaymentURI{Address: testAddress},
+	}, {
+		name: "double slash form",
+		uri:  "decred://" + testAddress + "?amount=1.5",
+		want: PaymentURI{Address: testAddress, Amount: 150000000},
+	}, {
+		name: "scheme case and surrounding spaces",
+		uri:  "  DeCred:" + testAddress + "  ",
+		want: PaymentURI{Address: testAddress},
+	}, {
+		name: "label and message",
+		uri:  "decred:" + testAddress + "?label=Coffee%20shop&message=Two+cups",
+		want: PaymentURI{Address: testAddress, Label: "Coffee shop", Message: "Two cups"},
+	}, {
+		name: "zero amount",
+		uri:  "decred:" + testAddress + "?amount=0",
+		want: PaymentURI{Address: testAddress},
+	}, {
+		name: "maximum amount",
+		uri:  "decred:" + testAddress + "?amount=21000000",
+		want: PaymentURI{Address: testAddress, Amount: dcrutil.MaxAmount},
+	}, {
+		name: "optional unknown parameter",
+		uri:  "decred:" + testAddress + "?foo=bar",
+		want: PaymentURI{Address: testAddress},
+	}, {
+		name:    "required unknown parameter",
+		uri:     "decred:" + testAddress + "?req-foo=bar",
+		wantErr: true,
+	}, {
+		name:    "negative amount",
+		uri:     "decred:" + testAddress + "?amount=-1",
+		wantErr: true,
+	}, {
+		name:    "amount above the maximum",
+		uri:     "decred:" + testAddress + "?amount=21000000.00000001",
+		wantErr: true,
+	}, {
+		name:    "amount not a number",
+		uri:     "decred:" + testAddress + "?amount=one",
+		wantErr: true,
+	}, {
+		name:    "amount NaN",
+		uri:     "decred:" + testAddress + "?amount=NaN",
+		wantErr: true,
+	}, {
+		name:    "no address",
+		uri:     "decred:?amount=1",
+		wantErr: true,
+	}, {
+		name:    "other scheme",
+		uri:     "bitcoin:" + testAddress,
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		uri, err := ParsePaymentURI(test.uri)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %+v", test.name, *uri)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if *uri != test.want {
+			t.Errorf("%s: got %+v, want %+v", test.name, *uri, test.want)
+		}
+	}
+}
+
+func TestPaymentURIString(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  PaymentURI
+		want string
+	}{{
+		name: "address only",
+		uri:  PaymentURI{Address: testAddress},
+		want: "decred:" + testAddress,
+	}, {
+		name: "amount",
+		uri:  PaymentURI{Address: testAddress, Amount: 150000000},
+		want: "decred:" + testAddress + "?amount=1.5",
+	}, {
+		name: "spaces escaped as %20",
+		uri:  PaymentURI{Address: testAddress, Label: "Coffee shop", Message: "a+b & c"},
+		want: "decred:" + testAddress + "?label=Coffee%20shop&message=a%2Bb%20%26%20c",
+	}, {
+		name: "all parameters",
+		uri:  PaymentURI{Address: testAddress, Amount: 1, Label: "l", Message: "m"},
+		want: "decred:" + testAddress + "?amount=0.00000001&label=l&message=m",
+	}}
+
+	for _, test := range tests {
+		s := test.uri.String()
+		if s != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, s, test.want)
+			continue
+		}
+
+		// the encoding must parse back to the same request
+		uri, err := ParsePaymentURI(s)
+		if err != nil {
+			t.Errorf("%s: parsing %q: %v", test.name, s, err)
+			continue
+		}
+		if *uri != test.uri {
+			t.Errorf("%s: round trip got %+v, want %+v", test.name, *uri, test.uri)
+		}
+	}
+}
@@ -19,40 +19,28 @@ type Proposal struct {
 }
 
 func (l *listener) OnNewProposal(proposal *dcrlibwallet.Proposal) {
-	l.Send <- SyncStatusUpdate{
-		Stage: ProposalAdded,
-		Proposal: Proposal{
-			Proposal:       proposal,
-			ProposalStatus: NewProposalFound,
-		},
-	}
+	l.events.PublishProposal(Proposal{
+		Proposal:       proposal,
+		ProposalStatus: NewProposalFound,
+	})
 }
 
 func (l *listener) OnProposalVoteStarted(proposal *dcrlibwallet.Proposal) {
-	l.Send <- SyncStatusUpdate{
-		Stage: ProposalVoteStarted,
-		Proposal: Proposal{
-			Proposal:       proposal,
-			ProposalStatus: VoteStarted,
-		},
-	}
+	l.events.PublishProposal(Proposal{
+		Proposal:       proposal,
+		ProposalStatus: VoteStarted,
+	})
 }
 
 func (l *listener) OnProposalVoteFinished(proposal *dcrlibwallet.Proposal) {
-	l.Send <- SyncStatusUpdate{
-		Stage: ProposalVoteFinished,
-		Proposal: Proposal{
-			Proposal:       proposal,
-			ProposalStatus: VoteFinished,
-		},
-	}
+	l.events.PublishProposal(Proposal{
+		Proposal:       proposal,
+		ProposalStatus: VoteFinished,
+	})
 }
 
 func (l *listener) OnProposalsSynced() {
-	l.Send <- SyncStatusUpdate{
-		Stage: ProposalSynced,
-		Proposal: Proposal{
-			ProposalStatus: Synced,
-		},
-	}
+	l.events.PublishProposal(Proposal{
+		ProposalStatus: Synced,
+	})
 }
@@ -0,0 +1,164 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRateCacheTTL is how long a fetched rate is served from the cache
+// before the sources are queried again.
+const DefaultRateCacheTTL = 5 * time.Minute
+
+// ExchangeRate is a DCR price returned by a RateSource.
+type ExchangeRate struct {
+	Currency  string
+	Rate      float64
+	Source    string
+	FetchedAt time.Time
+}
+
+// rateFailureBackoff is how long the sources are not queried again for a
+// currency after all of them failed.
+const rateFailureBackoff = 30 * time.Second
+
+// RateCache caches the rates returned by a list of RateSources. The sources
+// are queried in order and the first successful response is used, so a
+// single unreachable source does not leave the app without a rate.
+type RateCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	sources []RateSource
+	rates   map[string]*cachedRate
+}
+
+// cachedRate is the last rate fetched for a currency and the state of its
+// next fetch.
+type cachedRate struct {
+	rate    ExchangeRate
+	hasRate bool
+
+	// err is the error of the last fetch if it failed at failedAt.
+	err      error
+	failedAt time.Time
+
+	// fetching is closed when the fetch in progress is done.
+	fetching chan struct{}
+}
+
+// NewRateCache returns a RateCache that queries sources in order and keeps
+// each result for ttl.
+func NewRateCache(ttl time.Duration, sources ...RateSource) *RateCache {
+	if ttl <= 0 {
+		ttl = DefaultRateCacheTTL
+	}
+
+	return &RateCache{
+		ttl:     ttl,
+		sources: sources,
+		rates:   make(map[string]*cachedRate),
+	}
+}
+
+// Rate returns the price of one DCR in currency. A cached rate is returned
+// if it has not expired. An expired rate is returned while the sources are
+// queried again in the background, and is kept if they all fail. Without a
+// cached rate, each source is queried until one succeeds and an error is
+// returned if none does. The sources are queried by one caller at a time,
+// and not again for a while after they all failed.
+func (rc *RateCache) Rate(currency string) (ExchangeRate, error) {
+	currency = strings.ToUpper(currency)
+
+	rc.mu.Lock()
+	cached, ok := rc.rates[currency]
+	if !ok {
+		cached = new(cachedRate)
+		rc.rates[currency] = cached
+	}
+
+	for cached.fetching != nil && !cached.hasRate {
+		fetching := cached.fetching
+		rc.mu.Unlock()
+		<-fetching
+		rc.mu.Lock()
+	}
+
+	rate, hasRate, err := cached.rate, cached.hasRate, cached.err
+	fresh := hasRate && time.Since(rate.FetchedAt) < rc.ttl
+	backoff := err != nil && time.Since(cached.failedAt) < rateFailureBackoff
+	if fresh || cached.fetching != nil || backoff {
+		rc.mu.Unlock()
+		if hasRate {
+			return rate, nil
+		}
+		return ExchangeRate{}, err
+	}
+
+	fetching := make(chan struct{})
+	cached.fetching = fetching
+	rc.mu.Unlock()
+
+	if hasRate {
+		go rc.refresh(currency, cached, fetching)
+		return rate, nil
+	}
+	return rc.refresh(currency, cached, fetching)
+}
+
+// refresh fetches the rate of currency into cached and closes fetching when
+// it is done.
+func (rc *RateCache) refresh(currency string, cached *cachedRate, fetching chan struct{}) (ExchangeRate, error) {
+	rate, err := rc.fetch(currency)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if err == nil {
+		cached.rate, cached.hasRate = rate, true
+		cached.err, cached.failedAt = nil, time.Time{}
+	} else {
+		cached.err, cached.failedAt = err, time.Now()
+		if cached.hasRate {
+			log.Warnf("All rate sources failed, using %s rate from %s fetched at %v", currency,
+				cached.rate.Source, cached.rate.FetchedAt)
+		}
+	}
+	cached.fetching = nil
+	close(fetching)
+
+	return rate, err
+}
+
+// fetch queries each source for the rate of currency until one succeeds.
+func (rc *RateCache) fetch(currency string) (ExchangeRate, error) {
+	var errs []string
+	for _, source := range rc.sources {
+		rate, err := source.Rate(currency)
+		if err != nil {
+			log.Debugf("Rate source %s failed for %s: %v", source.Name(), currency, err)
+			errs = append(errs, fmt.Sprintf("%s: %v", source.Name(), err))
+			continue
+		}
+
+		return ExchangeRate{
+			Currency:  currency,
+			Rate:      rate,
+			Source:    source.Name(),
+			FetchedAt: time.Now(),
+		}, nil
+	}
+
+	if len(errs) == 0 {
+		return ExchangeRate{}, fmt.Errorf("no rate source configured")
+	}
+
+	return ExchangeRate{}, fmt.Errorf("could not fetch %s rate: %s", currency, strings.Join(errs, "; "))
+}
+
+// Clear drops all cached rates so the next request queries the sources.
+func (rc *RateCache) Clear() {
+	rc.mu.Lock()
+	rc.rates = make(map[string]*cachedRate)
+	rc.mu.Unlock()
+}
@@ -0,0 +1,142 @@
+package wallet
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testRateSource is a RateSource that returns a set rate or error and
+// counts its queries.
+type testRateSource struct {
+	name string
+
+	mu    sync.Mutex
+	rate  float64
+	err   error
+	calls int
+}
+
+func (s *testRateSource) Name() string {
+	return s.name
+}
+
+func (s *testRateSource) Rate(currency string) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return s.rate, s.err
+}
+
+func (s *testRateSource) set(rate float64, err error) {
+	s.mu.Lock()
+	s.rate, s.err = rate, err
+	s.mu.Unlock()
+}
+
+func (s *testRateSource) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// waitForRefresh waits for the background fetch of currency, if any.
+func waitForRefresh(rc *RateCache, currency string) {
+	rc.mu.Lock()
+	var fetching chan struct{}
+	if cached, ok := rc.rates[currency]; ok {
+		fetching = cached.fetching
+	}
+	rc.mu.Unlock()
+
+	if fetching != nil {
+		<-fetching
+	}
+}
+
+func TestRateCacheFailover(t *testing.T) {
+	first := &testRateSource{name: "first", err: errors.New("unreachable")}
+	second := &testRateSource{name: "second", rate: 25.5}
+	third := &testRateSource{name: "third", rate: 30}
+	rc := NewRateCache(time.Hour, first, second, third)
+
+	rate, err := rc.Rate("usd")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rate.Source != "second" || rate.Rate != 25.5 || rate.Currency != "USD" {
+		t.Fatalf("got %+v, want the USD rate of the second source", rate)
+	}
+	if third.callCount() != 0 {
+		t.Fatalf("third source queried after the second succeeded")
+	}
+
+	// a fresh rate is served from the cache
+	if _, err := rc.Rate("USD"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.callCount() != 1 || second.callCount() != 1 {
+		t.Fatalf("sources queried again for a fresh rate: %d, %d", first.callCount(), second.callCount())
+	}
+}
+
+func TestRateCacheAllSourcesFail(t *testing.T) {
+	first := &testRateSource{name: "first", err: errors.New("unreachable")}
+	second := &testRateSource{name: "second", err: errors.New("bad response")}
+	rc := NewRateCache(time.Hour, first, second)
+
+	if _, err := rc.Rate("USD"); err == nil {
+		t.Fatalf("expected an error when all sources fail")
+	}
+
+	// the failure is kept for a while instead of querying the sources on
+	// every request
+	second.set(20, nil)
+	if _, err := rc.Rate("USD"); err == nil {
+		t.Fatalf("expected the cached error during the backoff")
+	}
+	if first.callCount() != 1 || second.callCount() != 1 {
+		t.Fatalf("sources queried during the backoff: %d, %d", first.callCount(), second.callCount())
+	}
+
+	if _, err := NewRateCache(time.Hour).Rate("USD"); err == nil {
+		t.Fatalf("expected an error without sources")
+	}
+}
+
+func TestRateCacheStaleRate(t *testing.T) {
+	source := &testRateSource{name: "source", rate: 25}
+	rc := NewRateCache(time.Millisecond, source)
+
+	if _, err := rc.Rate("USD"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the expired rate is returned while it is fetched again, and kept when
+	// the fetch fails
+	source.set(0, errors.New("unreachable"))
+	time.Sleep(5 * time.Millisecond)
+	rate, err := rc.Rate("USD")
+	if err != nil || rate.Rate != 25 {
+		t.Fatalf("got %+v, %v, want the expired rate", rate, err)
+	}
+	waitForRefresh(rc, "USD")
+	if source.callCount() != 2 {
+		t.Fatalf("expired rate not fetched again, %d queries", source.callCount())
+	}
+
+	rate, err = rc.Rate("USD")
+	if err != nil || rate.Rate != 25 {
+		t.Fatalf("got %+v, %v, want the expired rate after the sources failed", rate, err)
+	}
+	if source.callCount() != 2 {
+		t.Fatalf("sources queried during the backoff, %d queries", source.callCount())
+	}
+
+	// Clear drops the stale rate
+	rc.Clear()
+	if _, err := rc.Rate("USD"); err == nil {
+		t.Fatalf("expected an error after clearing the cache")
+	}
+}
@@ -0,0 +1,206 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// BittrexRateSource is the name of the Bittrex ticker source.
+	BittrexRateSource = "bittrex"
+
+	// BinanceRateSource is the name of the Binance ticker source.
+	BinanceRateSource = "binance"
+
+	// DcrdataRateSource is the name of the dcrdata exchange aggregator source.
+	DcrdataRateSource = "dcrdata"
+
+	// FileRateSource is the name of the local JSON file source.
+	FileRateSource = "file"
+
+	rateRequestTimeout = 10 * time.Second
+)
+
+var (
+	// ErrUnsupportedCurrency is returned by a RateSource that cannot
+	// price DCR in the requested currency.
+	ErrUnsupportedCurrency = errors.New("currency not supported by rate source")
+
+	// DefaultRateSources lists the sources queried, in order, when none
+	// are configured.
+	DefaultRateSources = []string{BittrexRateSource, BinanceRateSource, DcrdataRateSource}
+)
+
+// RateSource is implemented by every backend that can price DCR in a fiat
+// currency.
+type RateSource interface {
+	// Name returns the identifier used for the source in the config.
+	Name() string
+
+	// Rate returns the price of one DCR in currency, an ISO 4217 code.
+	Rate(currency string) (float64, error)
+}
+
+// NewRateSource returns the RateSource identified by name. rateFile is only
+// used by the file source.
+func NewRateSource(name, rateFile string) (RateSource, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case BittrexRateSource:
+		return &bittrexSource{}, nil
+	case BinanceRateSource:
+		return &binanceSource{}, nil
+	case DcrdataRateSource:
+		return &dcrdataSource{}, nil
+	case FileRateSource:
+		if rateFile == "" {
+			return nil, fmt.Errorf("rate source %q requires a rate file", FileRateSource)
+		}
+		return &fileSource{path: rateFile}, nil
+	default:
+		return nil, fmt.Errorf("unknown rate source %q", name)
+	}
+}
+
+// getJSON performs a GET request on url and decodes the response body into target.
+func getJSON(url string, target interface{}) error {
+	client := http.Client{Timeout: rateRequestTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("non 200 response from server: %v", string(b))
+	}
+
+	return json.Unmarshal(b, target)
+}
+
+// usdtMarket returns true if currency can be priced using the USDT markets
+// of the exchange sources.
+func usdtMarket(currency string) bool {
+	return strings.EqualFold(currency, "USD")
+}
+
+// bittrexSource prices DCR using the Bittrex DCR-USDT ticker.
+type bittrexSource struct{}
+
+func (s *bittrexSource) Name() string {
+	return BittrexRateSource
+}
+
+func (s *bittrexSource) Rate(currency string) (float64, error) {
+	if !usdtMarket(currency) {
+		return 0, ErrUnsupportedCurrency
+	}
+
+	var ticker struct {
+		LastTradeRate string
+	}
+	err := getJSON("https://api.bittrex.com/v3/markets/DCR-USDT/ticker", &ticker)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(ticker.LastTradeRate, 64)
+}
+
+// binanceSource prices DCR using the Binance DCRUSDT ticker.
+type binanceSource struct{}
+
+func (s *binanceSource) Name() string {
+	return BinanceRateSource
+}
+
+func (s *binanceSource) Rate(currency string) (float64, error) {
+	if !usdtMarket(currency) {
+		return 0, ErrUnsupportedCurrency
+	}
+
+	var ticker struct {
+		Price string `json:"price"`
+	}
+	err := getJSON("https://api.binance.com/api/v3/ticker/price?symbol=DCRUSDT", &ticker)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(ticker.Price, 64)
+}
+
+// dcrdataSource prices DCR using the volume weighted index computed by
+// dcrdata from all the exchanges it tracks.
+type dcrdataSource struct{}
+
+func (s *dcrdataSource) Name() string {
+	return DcrdataRateSource
+}
+
+func (s *dcrdataSource) Rate(currency string) (float64, error) {
+	var state struct {
+		Index string  `json:"btc_index"`
+		Price float64 `json:"price"`
+	}
+	url := "https://explorer.dcrdata.org/api/exchanges?code=" + strings.ToUpper(currency)
+	err := getJSON(url, &state)
+	if err != nil {
+		return 0, err
+	}
+
+	if !strings.EqualFold(state.Index, currency) {
+		return 0, ErrUnsupportedCurrency
+	}
+
+	if state.Price <= 0 {
+		return 0, fmt.Errorf("dcrdata returned no %s price", currency)
+	}
+
+	return state.Price, nil
+}
+
+// fileSource reads rates from a local JSON file mapping currency codes to
+// the price of one DCR, e.g. {"USD": 25.1}. The file is re-read on every
+// request so it can be updated while the app is running.
+type fileSource struct {
+	path string
+}
+
+func (s *fileSource) Name() string {
+	return FileRateSource
+}
+
+func (s *fileSource) Rate(currency string) (float64, error) {
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return 0, err
+	}
+
+	var rates map[string]float64
+	err = json.Unmarshal(b, &rates)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate file %s: %v", s.path, err)
+	}
+
+	for code, rate := range rates {
+		if strings.EqualFold(code, currency) {
+			if rate <= 0 {
+				return 0, fmt.Errorf("invalid %s rate %v in %s", code, rate, s.path)
+			}
+			return rate, nil
+		}
+	}
+
+	return 0, ErrUnsupportedCurrency
+}
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileRateSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := ioutil.WriteFile(path, []byte(`{"USD": 25.1, "eur": 21.5, "GBP": 0, "JPY": -3}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := NewRateSource(FileRateSource, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		currency string
+		rate     float64
+		wantErr  bool
+	}{
+		{"USD", 25.1, false},
+		{"EUR", 21.5, false},
+		{"GBP", 0, true},
+		{"JPY", 0, true},
+		{"CAD", 0, true},
+	}
+	for _, test := range tests {
+		rate, err := source.Rate(test.currency)
+		if (err != nil) != test.wantErr || rate != test.rate {
+			t.Errorf("%s: got %v, %v, want %v with error %v", test.currency, rate, err, test.rate, test.wantErr)
+		}
+	}
+
+	if _, err := NewRateSource(FileRateSource, ""); err == nil {
+		t.Errorf("expected an error without a rate file")
+	}
+}
@@ -0,0 +1,169 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrutil/v3"
+	"github.com/planetdecred/dcrlibwallet/addresshelper"
+	"github.com/planetdecred/dcrlibwallet/txhelper"
+	"github.com/planetdecred/dcrlibwallet/utils"
+)
+
+// ErrTxNotOwned is returned when republishing a transaction that was not
+// created by any of the loaded wallets. dcrlibwallet cannot publish raw
+// transactions, so transactions signed elsewhere cannot be broadcast.
+var ErrTxNotOwned = errors.New("transaction was not created by a loaded wallet, only unmined transactions of the loaded wallets can be republished")
+
+// errTxChanged is returned when republishing a transaction that differs
+// from the one recorded by the wallet with the same hash.
+var errTxChanged = errors.New("transaction differs from the one recorded by the wallet")
+
+// RawTxInput is a decoded input of a raw transaction.
+type RawTxInput struct {
+	PreviousOutpoint string
+	Amount           dcrutil.Amount
+	Signed           bool
+}
+
+// RawTxOutput is a decoded output of a raw transaction.
+type RawTxOutput struct {
+	Addresses []string
+	Amount    dcrutil.Amount
+}
+
+// RawTransaction is a human-readable summary of a serialized transaction.
+type RawTransaction struct {
+	Hash string
+
+	// WalletID is the ID of the loaded wallet that created the
+	// transaction, or -1 if none did.
+	WalletID int
+
+	Inputs  []RawTxInput
+	Outputs []RawTxOutput
+	Fee     dcrutil.Amount
+	FeeRate dcrutil.Amount
+	Size    int
+
+	// Problems lists anything that would make the network reject the
+	// transaction or keep it from being republished.
+	Problems []string
+}
+
+// DecodeRawTransaction decodes a hex encoded transaction into a summary of
+// its inputs, outputs, fee and size. Transactions that are not unmined
+// transactions of a loaded wallet are listed with a problem, as only those
+// can be republished.
+func (wal *Wallet) DecodeRawTransaction(txHex string) (*RawTransaction, error) {
+	txHex = strings.TrimSpace(txHex)
+	msgTx, fee, size, feeRate, err := txhelper.MsgTxFeeSizeRate(txHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hex: %v", err)
+	}
+
+	params, err := utils.ChainParams(wal.multi.NetType())
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &RawTransaction{
+		Hash:     msgTx.TxHash().String(),
+		WalletID: -1,
+		Fee:      fee,
+		FeeRate:  feeRate,
+		Size:     size,
+	}
+
+	walletID, err := wal.ownedUnminedTx(tx.Hash, txHex)
+	if err != nil {
+		tx.Problems = append(tx.Problems, err.Error())
+	} else {
+		tx.WalletID = walletID
+	}
+
+	if len(msgTx.TxIn) == 0 {
+		tx.Problems = append(tx.Problems, "transaction has no inputs")
+	}
+	if len(msgTx.TxOut) == 0 {
+		tx.Problems = append(tx.Problems, "transaction has no outputs")
+	}
+
+	for i, in := range msgTx.TxIn {
+		input := RawTxInput{
+			PreviousOutpoint: fmt.Sprintf("%s:%d", in.PreviousOutPoint.Hash, in.PreviousOutPoint.Index),
+			Amount:           dcrutil.Amount(in.ValueIn),
+			Signed:           len(in.SignatureScript) > 0,
+		}
+		if !input.Signed {
+			tx.Problems = append(tx.Problems, fmt.Sprintf("input %d is not signed", i))
+		}
+		tx.Inputs = append(tx.Inputs, input)
+	}
+
+	for i, out := range msgTx.TxOut {
+		addresses, err := addresshelper.PkScriptAddresses(params, out.PkScript)
+		if err != nil {
+			tx.Problems = append(tx.Problems, fmt.Sprintf("output %d: %v", i, err))
+		}
+		if out.Value < 0 {
+			tx.Problems = append(tx.Problems, fmt.Sprintf("output %d has a negative amount", i))
+		}
+		tx.Outputs = append(tx.Outputs, RawTxOutput{
+			Addresses: addresses,
+			Amount:    dcrutil.Amount(out.Value),
+		})
+	}
+
+	if fee < 0 {
+		tx.Problems = append(tx.Problems, "outputs spend more than the inputs")
+	}
+
+	return tx, nil
+}
+
+// ownedUnminedTx returns the ID of the wallet that created the unmined
+// transaction with txHash and the serialization txHex.
+func (wal *Wallet) ownedUnminedTx(txHash, txHex string) (int, error) {
+	wallets, err := wal.wallets()
+	if err != nil {
+		return -1, err
+	}
+
+	for _, w := range wallets {
+		tx, err := w.GetTransactionRaw(txHash)
+		if err != nil {
+			continue
+		}
+
+		if tx.BlockHeight != -1 {
+			return -1, errors.New("transaction is already mined")
+		}
+		if !strings.EqualFold(tx.Hex, txHex) {
+			return -1, errTxChanged
+		}
+		return w.ID, nil
+	}
+
+	return -1, ErrTxNotOwned
+}
+
+// RepublishTransaction publishes the hex encoded transaction again if it is
+// an unmined transaction of a loaded wallet. dcrlibwallet only republishes
+// all unmined transactions of a wallet, so the other unmined transactions of
+// the wallet are published again along with it.
+func (wal *Wallet) RepublishTransaction(txHex string) error {
+	txHex = strings.TrimSpace(txHex)
+	msgTx, _, _, _, err := txhelper.MsgTxFeeSizeRate(txHex)
+	if err != nil {
+		return fmt.Errorf("invalid transaction hex: %v", err)
+	}
+
+	walletID, err := wal.ownedUnminedTx(msgTx.TxHash().String(), txHex)
+	if err != nil {
+		return err
+	}
+
+	return wal.multi.WalletWithID(walletID).PublishUnminedTransactions()
+}
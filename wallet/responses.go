@@ -4,27 +4,6 @@ import (
 	"github.com/planetdecred/dcrlibwallet"
 )
 
-// Response represents a discriminated union for wallet responses.
-// Either Resp or Err must be nil.
-type Response struct {
-	Resp interface{}
-	Err  error
-}
-
-// ResponseError wraps err in a Response
-func ResponseError(err error) Response {
-	return Response{
-		Err: err,
-	}
-}
-
-// ResponseResp wraps resp in a Response
-func ResponseResp(resp interface{}) Response {
-	return Response{
-		Resp: resp,
-	}
-}
-
 // MultiWalletInfo represents bulk information about the wallets returned by the wallet backend
 type MultiWalletInfo struct {
 	LoadedWallets   int
@@ -84,12 +63,6 @@ type UpdatedAccount struct {
 	ID int32
 }
 
-// LoadedWallets is sent when then the Wallet is done loading wallets
-type LoadedWallets struct {
-	Count              int32
-	StartUpSecuritySet bool
-}
-
 // Restored is sent when the Wallet is done restoring a wallet
 type Restored struct{}
 
@@ -0,0 +1,320 @@
+package wallet
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// maxSyncEvents is the number of sync events kept in the sync history.
+	maxSyncEvents = 500
+
+	// maxSyncSessions is the number of sync sessions kept on disk.
+	maxSyncSessions = 30
+
+	// syncSessionsFile is the file in the network directory the sync
+	// sessions are saved to.
+	syncSessionsFile = "sync_sessions.json"
+)
+
+// Results of a sync session.
+const (
+	SyncResultSyncing     = "syncing"
+	SyncResultCompleted   = "completed"
+	SyncResultCanceled    = "canceled"
+	SyncResultFailed      = "failed"
+	SyncResultInterrupted = "interrupted"
+)
+
+// SyncEvent is a change of the sync stage recorded in the sync history.
+type SyncEvent struct {
+	Time           time.Time
+	Stage          string
+	Height         int32  `json:",omitempty"`
+	ConnectedPeers int32  `json:",omitempty"`
+	Error          string `json:",omitempty"`
+}
+
+// SyncStageTiming is the time a sync session spent in one stage. The
+// heights are only set for the stages that go through blocks.
+type SyncStageTiming struct {
+	Stage       string
+	Start       time.Time
+	Duration    time.Duration
+	StartHeight int32 `json:",omitempty"`
+	EndHeight   int32 `json:",omitempty"`
+}
+
+// BlocksPerSecond returns the rate blocks were processed at in the stage.
+func (t SyncStageTiming) BlocksPerSecond() float64 {
+	if t.Duration <= 0 {
+		return 0
+	}
+	return float64(t.EndHeight-t.StartHeight) / t.Duration.Seconds()
+}
+
+// SyncSession is a sync from its start until it completes, is canceled or
+// fails.
+type SyncSession struct {
+	Start    time.Time
+	End      time.Time
+	Result   string
+	Error    string `json:",omitempty"`
+	Stages   []SyncStageTiming
+	MinPeers int32
+	MaxPeers int32
+}
+
+// Duration returns how long the session took, or has taken so far.
+func (s SyncSession) Duration() time.Duration {
+	if s.End.IsZero() {
+		return time.Since(s.Start)
+	}
+	return s.End.Sub(s.Start)
+}
+
+// BlocksPerSecond returns the rate blocks were processed at over the
+// stages that go through blocks.
+func (s SyncSession) BlocksPerSecond() float64 {
+	var blocks int32
+	var duration time.Duration
+	for _, stage := range s.Stages {
+		if stage.EndHeight > stage.StartHeight {
+			blocks += stage.EndHeight - stage.StartHeight
+			duration += stage.Duration
+		}
+	}
+
+	if duration <= 0 {
+		return 0
+	}
+	return float64(blocks) / duration.Seconds()
+}
+
+// syncHistory records the sync stages the multiwallet goes through so sync
+// failures can be diagnosed after the fact. Progress reports are only
+// recorded as events when a new stage starts. Every sync is also recorded
+// as a session with the time spent in each stage, and the sessions are
+// saved to disk when they end.
+type syncHistory struct {
+	mu     sync.Mutex
+	events []SyncEvent
+
+	// progressStage is the progress stage the sync is in, if any.
+	progressStage SyncNotificationType
+
+	path     string
+	sessions []SyncSession
+	current  *SyncSession
+	peers    int32
+}
+
+// load reads the sync sessions saved at path and saves new sessions there.
+func (h *syncHistory) load(path string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.path = path
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Errorf("Error reading sync sessions: %v", err)
+		}
+		return
+	}
+
+	if err := json.Unmarshal(data, &h.sessions); err != nil {
+		log.Errorf("Error decoding sync sessions: %v", err)
+	}
+}
+
+func (h *syncHistory) record(stage SyncNotificationType, event SyncEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	event.Time = time.Now()
+	event.Stage = stage.String()
+	h.updateSession(stage, event)
+
+	switch stage {
+	case CfiltersFetchProgress, HeadersFetchProgress, AddressDiscoveryProgress, HeadersRescanProgress:
+		if stage == h.progressStage {
+			return
+		}
+		h.progressStage = stage
+	case SyncStarted, SyncCompleted, SyncCanceled:
+		h.progressStage = stage
+	}
+
+	if len(h.events) == maxSyncEvents {
+		h.events = append(h.events[:0], h.events[1:]...)
+	}
+	h.events = append(h.events, event)
+}
+
+func (h *syncHistory) updateSession(stage SyncNotificationType, event SyncEvent) {
+	switch stage {
+	case SyncStarted:
+		if h.current != nil {
+			h.endSession(event.Time, SyncResultInterrupted, "")
+		}
+		h.current = &SyncSession{
+			Start:    event.Time,
+			Result:   SyncResultSyncing,
+			MinPeers: h.peers,
+			MaxPeers: h.peers,
+		}
+
+	case PeersConnected:
+		h.peers = event.ConnectedPeers
+		if h.current == nil {
+			return
+		}
+		if h.peers < h.current.MinPeers {
+			h.current.MinPeers = h.peers
+		}
+		if h.peers > h.current.MaxPeers {
+			h.current.MaxPeers = h.peers
+		}
+
+	case CfiltersFetchProgress, HeadersFetchProgress, AddressDiscoveryProgress, HeadersRescanProgress:
+		if h.current == nil {
+			return
+		}
+
+		stages := h.current.Stages
+		if len(stages) > 0 && stages[len(stages)-1].Stage == event.Stage {
+			last := &stages[len(stages)-1]
+			last.Duration = event.Time.Sub(last.Start)
+			last.EndHeight = event.Height
+			return
+		}
+
+		h.endStage(event.Time)
+		h.current.Stages = append(h.current.Stages, SyncStageTiming{
+			Stage:       event.Stage,
+			Start:       event.Time,
+			StartHeight: event.Height,
+			EndHeight:   event.Height,
+		})
+
+	case SyncCompleted:
+		h.endSession(event.Time, SyncResultCompleted, "")
+
+	case SyncCanceled:
+		if event.Error != "" {
+			h.endSession(event.Time, SyncResultFailed, event.Error)
+		} else {
+			h.endSession(event.Time, SyncResultCanceled, "")
+		}
+	}
+}
+
+// endStage sets the duration of the last stage of the current session.
+func (h *syncHistory) endStage(end time.Time) {
+	stages := h.current.Stages
+	if len(stages) > 0 {
+		last := &stages[len(stages)-1]
+		last.Duration = end.Sub(last.Start)
+	}
+}
+
+// endSession ends the current session with result and saves it.
+func (h *syncHistory) endSession(end time.Time, result, errMsg string) {
+	if h.current == nil {
+		return
+	}
+
+	h.endStage(end)
+	h.current.End = end
+	h.current.Result = result
+	h.current.Error = errMsg
+
+	h.sessions = append(h.sessions, *h.current)
+	if len(h.sessions) > maxSyncSessions {
+		h.sessions = h.sessions[len(h.sessions)-maxSyncSessions:]
+	}
+	h.current = nil
+
+	if err := h.save(); err != nil {
+		log.Errorf("Error saving sync sessions: %v", err)
+	}
+}
+
+func (h *syncHistory) save() error {
+	if h.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(h.sessions)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(h.path, data, 0600)
+}
+
+func (h *syncHistory) list() []SyncEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	events := make([]SyncEvent, len(h.events))
+	copy(events, h.events)
+	return events
+}
+
+func (h *syncHistory) listSessions() []SyncSession {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sessions := make([]SyncSession, len(h.sessions), len(h.sessions)+1)
+	copy(sessions, h.sessions)
+	if h.current != nil {
+		current := *h.current
+		current.Stages = append([]SyncStageTiming(nil), h.current.Stages...)
+		sessions = append(sessions, current)
+	}
+	return sessions
+}
+
+// String returns the name of a sync stage.
+func (t SyncNotificationType) String() string {
+	switch t {
+	case SyncStarted:
+		return "sync started"
+	case SyncCanceled:
+		return "sync canceled"
+	case SyncCompleted:
+		return "sync completed"
+	case CfiltersFetchProgress:
+		return "fetching cfilters"
+	case HeadersFetchProgress:
+		return "fetching headers"
+	case AddressDiscoveryProgress:
+		return "discovering addresses"
+	case HeadersRescanProgress:
+		return "rescanning headers"
+	case PeersConnected:
+		return "peers changed"
+	default:
+		return "unknown"
+	}
+}
+
+// SyncHistory returns the sync events since the app started, oldest first.
+func (wal *Wallet) SyncHistory() []SyncEvent {
+	return wal.syncHistory.list()
+}
+
+// SyncSessions returns the recent sync sessions, oldest first. A sync in
+// progress is returned last with the SyncResultSyncing result.
+func (wal *Wallet) SyncSessions() []SyncSession {
+	return wal.syncHistory.listSessions()
+}
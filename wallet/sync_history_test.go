@@ -0,0 +1,132 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSyncHistorySession(t *testing.T) {
+	var h syncHistory
+
+	h.record(PeersConnected, SyncEvent{ConnectedPeers: 2})
+	h.record(SyncStarted, SyncEvent{})
+	h.record(CfiltersFetchProgress, SyncEvent{Height: 10})
+	h.record(CfiltersFetchProgress, SyncEvent{Height: 50})
+	h.record(PeersConnected, SyncEvent{ConnectedPeers: 5})
+	h.record(HeadersFetchProgress, SyncEvent{Height: 50})
+	h.record(PeersConnected, SyncEvent{ConnectedPeers: 1})
+	h.record(HeadersFetchProgress, SyncEvent{Height: 120})
+
+	sessions := h.listSessions()
+	if len(sessions) != 1 || sessions[0].Result != SyncResultSyncing {
+		t.Fatalf("got %+v, want the session in progress", sessions)
+	}
+
+	h.record(SyncCompleted, SyncEvent{})
+	sessions = h.listSessions()
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+
+	s := sessions[0]
+	if s.Result != SyncResultCompleted || s.End.IsZero() || s.Error != "" {
+		t.Fatalf("got %+v, want a completed session", s)
+	}
+	if s.MinPeers != 1 || s.MaxPeers != 5 {
+		t.Fatalf("got peers %d-%d, want 1-5", s.MinPeers, s.MaxPeers)
+	}
+
+	// progress reports of the same stage extend the stage
+	if len(s.Stages) != 2 {
+		t.Fatalf("got %d stages, want 2: %+v", len(s.Stages), s.Stages)
+	}
+	cfilters, headers := s.Stages[0], s.Stages[1]
+	if cfilters.Stage != CfiltersFetchProgress.String() || cfilters.StartHeight != 10 || cfilters.EndHeight != 50 {
+		t.Fatalf("got cfilters stage %+v", cfilters)
+	}
+	if headers.Stage != HeadersFetchProgress.String() || headers.StartHeight != 50 || headers.EndHeight != 120 {
+		t.Fatalf("got headers stage %+v", headers)
+	}
+	if cfilters.Duration != headers.Start.Sub(cfilters.Start) || headers.Duration != s.End.Sub(headers.Start) {
+		t.Fatalf("stage durations do not add up to the session: %+v", s.Stages)
+	}
+
+	// only the start of each progress stage is an event
+	if events := h.list(); len(events) != 7 {
+		t.Fatalf("got %d events, want 7: %+v", len(events), events)
+	}
+}
+
+func TestSyncHistorySessionResults(t *testing.T) {
+	var h syncHistory
+
+	h.record(SyncStarted, SyncEvent{})
+	h.record(SyncStarted, SyncEvent{})
+	h.record(SyncCanceled, SyncEvent{})
+	h.record(SyncStarted, SyncEvent{})
+	h.record(SyncCanceled, SyncEvent{Error: "no peers"})
+
+	// progress and the end of a sync outside a session are ignored
+	h.record(HeadersFetchProgress, SyncEvent{Height: 10})
+	h.record(SyncCompleted, SyncEvent{})
+
+	sessions := h.listSessions()
+	want := []string{SyncResultInterrupted, SyncResultCanceled, SyncResultFailed}
+	if len(sessions) != len(want) {
+		t.Fatalf("got %d sessions, want %d", len(sessions), len(want))
+	}
+	for i, result := range want {
+		if sessions[i].Result != result {
+			t.Errorf("session %d: got result %q, want %q", i, sessions[i].Result, result)
+		}
+	}
+	if sessions[2].Error != "no peers" {
+		t.Errorf("got error %q, want the sync error", sessions[2].Error)
+	}
+}
+
+func TestSyncHistorySessionCap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "synchistory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "testnet3", syncSessionsFile)
+	var h syncHistory
+	h.load(path)
+
+	for i := 0; i < maxSyncSessions+5; i++ {
+		h.record(SyncStarted, SyncEvent{})
+		h.record(HeadersFetchProgress, SyncEvent{Height: int32(i)})
+		h.record(SyncCompleted, SyncEvent{})
+	}
+
+	// the oldest sessions are dropped
+	sessions := h.listSessions()
+	if len(sessions) != maxSyncSessions {
+		t.Fatalf("got %d sessions, want %d", len(sessions), maxSyncSessions)
+	}
+	if first := sessions[0].Stages[0].StartHeight; first != 5 {
+		t.Fatalf("got oldest session at height %d, want 5", first)
+	}
+
+	// the sessions are saved and loaded back with the session in progress
+	// left out
+	h.record(SyncStarted, SyncEvent{})
+	if len(h.listSessions()) != maxSyncSessions+1 {
+		t.Fatalf("session in progress not listed")
+	}
+
+	var loaded syncHistory
+	loaded.load(path)
+	sessions = loaded.listSessions()
+	if len(sessions) != maxSyncSessions {
+		t.Fatalf("got %d saved sessions, want %d", len(sessions), maxSyncSessions)
+	}
+	if last := sessions[len(sessions)-1].Stages[0].StartHeight; last != maxSyncSessions+4 {
+		t.Fatalf("got newest saved session at height %d, want %d", last, maxSyncSessions+4)
+	}
+}
@@ -0,0 +1,353 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil/v3"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// maxTicketBuyerDecisions is the number of decisions kept in the ticket
+	// buyer history.
+	maxTicketBuyerDecisions = 200
+
+	// ticketBuyerConfigKey is the user config key the ticket buyer policy
+	// is saved under.
+	ticketBuyerConfigKey = "godcr_ticket_buyer"
+
+	// TicketExpiry is the number of blocks a bought ticket can stay unmined
+	// before it expires. It is relative to the best block.
+	TicketExpiry = 256
+)
+
+// TicketBuyerConfig is the policy the automatic ticket buyer follows. Amounts
+// are in atoms.
+type TicketBuyerConfig struct {
+	WalletID int
+	Account  int32
+	VSPHost  string
+
+	// VSPFeePercentage is the fee of the VSP, used to estimate the cost of
+	// a ticket.
+	VSPFeePercentage float64
+
+	// BalanceToMaintain is the spendable balance left in the account.
+	BalanceToMaintain int64
+
+	// MaxPrice is the highest ticket price paid, 0 for any price.
+	MaxPrice int64
+
+	// MaxPerWindow is the number of tickets bought per ticket price window,
+	// 0 for no limit.
+	MaxPerWindow int32
+}
+
+func (c TicketBuyerConfig) Validate() error {
+	if c.VSPHost == "" {
+		return errors.New("no VSP selected")
+	}
+	if c.BalanceToMaintain < 0 || c.MaxPrice < 0 || c.MaxPerWindow < 0 {
+		return errors.New("the balance to maintain, maximum price and tickets per window cannot be negative")
+	}
+	return nil
+}
+
+// TicketBuyerDecision is a check of the ticket buyer policy, made when the
+// buyer starts or stops and when a block is attached. Consecutive checks
+// with the same outcome are recorded once with the latest time and height.
+type TicketBuyerDecision struct {
+	Time      time.Time
+	Height    int32
+	Purchased int32
+	Reason    string
+	Error     bool
+}
+
+// ticketBuyer buys tickets for an account whenever a block is attached and
+// its policy allows it. The spending passphrase is kept in memory while the
+// buyer runs and is cleared when it stops.
+type ticketBuyer struct {
+	mu         sync.Mutex
+	multi      *dcrlibwallet.MultiWallet
+	purchases  *ticketPurchases
+	windowSize int64
+
+	config     TicketBuyerConfig
+	passphrase []byte
+	running    bool
+	buying     bool
+
+	// window is the ticket price window boughtInWindow counts tickets for.
+	window         int64
+	boughtInWindow int32
+
+	decisions []TicketBuyerDecision
+}
+
+// record adds a decision to the history. It must be called with the lock
+// held.
+func (b *ticketBuyer) record(height, purchased int32, reason string, isErr bool) {
+	decision := TicketBuyerDecision{
+		Time:      time.Now(),
+		Height:    height,
+		Purchased: purchased,
+		Reason:    reason,
+		Error:     isErr,
+	}
+	log.Infof("Ticket buyer at height %d: %s", height, reason)
+
+	if n := len(b.decisions); n > 0 && purchased == 0 {
+		last := b.decisions[n-1]
+		if last.Purchased == 0 && last.Reason == reason && last.Error == isErr {
+			b.decisions[n-1] = decision
+			return
+		}
+	}
+
+	if len(b.decisions) == maxTicketBuyerDecisions {
+		b.decisions = append(b.decisions[:0], b.decisions[1:]...)
+	}
+	b.decisions = append(b.decisions, decision)
+}
+
+func (b *ticketBuyer) bestBlock() int32 {
+	if b.multi == nil {
+		return 0
+	}
+	return b.multi.GetBestBlock().Height
+}
+
+func (b *ticketBuyer) start(config TicketBuyerConfig, passphrase []byte) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if b.multi == nil {
+		return errors.New("wallets are not loaded")
+	}
+
+	w := b.multi.WalletWithID(config.WalletID)
+	if w == nil {
+		return fmt.Errorf("wallet %d not found", config.WalletID)
+	}
+	if w.IsWatchingOnlyWallet() {
+		return errors.New("watch only wallets cannot buy tickets")
+	}
+	if _, err := w.GetAccount(config.Account); err != nil {
+		return err
+	}
+
+	// check the passphrase now so a wrong one is not found at the first
+	// purchase. UnlockWallet clears the slice it is given.
+	wasLocked := w.IsLocked()
+	if err := w.UnlockWallet(append([]byte(nil), passphrase...)); err != nil {
+		return err
+	}
+	if wasLocked {
+		w.LockWallet()
+	}
+
+	b.multi.SaveUserConfigValue(ticketBuyerConfigKey, config)
+
+	b.mu.Lock()
+	b.config = config
+	b.passphrase = append([]byte(nil), passphrase...)
+	b.running = true
+	b.window = -1
+	b.record(b.bestBlock(), 0, fmt.Sprintf("started buying tickets for %s", w.Name), false)
+	b.mu.Unlock()
+
+	b.blockAttached(config.WalletID, b.bestBlock())
+	return nil
+}
+
+func (b *ticketBuyer) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.running {
+		return
+	}
+	for i := range b.passphrase {
+		b.passphrase[i] = 0
+	}
+	b.passphrase = nil
+	b.running = false
+	b.record(b.bestBlock(), 0, "stopped buying tickets", false)
+}
+
+// blockAttached checks the policy and buys tickets if it allows. Only blocks
+// attached to the wallet the buyer spends from are checked, and a check is
+// skipped while a purchase is in progress.
+func (b *ticketBuyer) blockAttached(walletID int, height int32) {
+	b.mu.Lock()
+	if !b.running || b.buying || walletID != b.config.WalletID {
+		b.mu.Unlock()
+		return
+	}
+	b.buying = true
+	config := b.config
+	passphrase := append([]byte(nil), b.passphrase...)
+	b.mu.Unlock()
+
+	go func() {
+		purchased, reason, err := b.buy(config, passphrase, height)
+		for i := range passphrase {
+			passphrase[i] = 0
+		}
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.buying = false
+		if !b.running {
+			return
+		}
+		if err != nil {
+			b.record(height, 0, err.Error(), true)
+			return
+		}
+		b.boughtInWindow += purchased
+		b.record(height, purchased, reason, false)
+	}()
+}
+
+// buy buys as many tickets as the policy allows at height and returns the
+// number bought with the reason for it.
+func (b *ticketBuyer) buy(config TicketBuyerConfig, passphrase []byte, height int32) (int32, string, error) {
+	if !b.multi.IsSynced() {
+		return 0, "waiting for the wallets to sync", nil
+	}
+
+	w := b.multi.WalletWithID(config.WalletID)
+	if w == nil {
+		return 0, "", fmt.Errorf("wallet %d not found", config.WalletID)
+	}
+
+	price, err := w.TicketPrice()
+	if err != nil {
+		return 0, "", err
+	}
+	if price.TicketPrice <= 0 {
+		return 0, "", errors.New("ticket price is not available")
+	}
+
+	balance, err := w.GetAccountBalance(config.Account)
+	if err != nil {
+		return 0, "", err
+	}
+
+	count, reason := b.ticketsToBuy(config, height, price.TicketPrice, balance.Spendable)
+	if count < 1 {
+		return 0, reason, nil
+	}
+
+	vsp, err := b.multi.NewVSPClient(config.VSPHost, config.WalletID, uint32(config.Account))
+	if err != nil {
+		return 0, "", err
+	}
+	start := time.Now()
+	if err := vsp.PurchaseTickets(int32(count), TicketExpiry, passphrase); err != nil {
+		return 0, "", err
+	}
+	b.purchases.add(TicketPurchase{
+		WalletID:      config.WalletID,
+		VSPHost:       config.VSPHost,
+		FeePercentage: config.VSPFeePercentage,
+		Count:         int32(count),
+		Start:         start,
+		End:           time.Now(),
+	})
+
+	return int32(count), fmt.Sprintf("bought %d ticket(s) at %s through %s", count,
+		dcrutil.Amount(price.TicketPrice), strings.TrimPrefix(config.VSPHost, "https://")), nil
+}
+
+// ticketsToBuy returns the number of tickets config allows buying at height
+// for the ticket price and the spendable balance of the account, or 0 with
+// the reason none can be bought. The count of tickets bought is reset when
+// height is in a new ticket price window.
+func (b *ticketBuyer) ticketsToBuy(config TicketBuyerConfig, height int32, price, spendable int64) (int64, string) {
+	if config.MaxPrice > 0 && price > config.MaxPrice {
+		return 0, fmt.Sprintf("ticket price %s is above the maximum of %s",
+			dcrutil.Amount(price), dcrutil.Amount(config.MaxPrice))
+	}
+
+	b.mu.Lock()
+	if b.windowSize > 0 {
+		if window := int64(height) / b.windowSize; window != b.window {
+			b.window = window
+			b.boughtInWindow = 0
+		}
+	}
+	bought := b.boughtInWindow
+	b.mu.Unlock()
+
+	if config.MaxPerWindow > 0 && bought >= config.MaxPerWindow {
+		return 0, fmt.Sprintf("%d of %d tickets bought in this window", bought, config.MaxPerWindow)
+	}
+
+	cost := price + int64(float64(price)*config.VSPFeePercentage/100)
+	count := (spendable - config.BalanceToMaintain) / cost
+	if count < 1 {
+		return 0, fmt.Sprintf("spendable balance %s is not enough for a ticket at %s while maintaining %s",
+			dcrutil.Amount(spendable), dcrutil.Amount(cost), dcrutil.Amount(config.BalanceToMaintain))
+	}
+	if config.MaxPerWindow > 0 && count > int64(config.MaxPerWindow-bought) {
+		count = int64(config.MaxPerWindow - bought)
+	}
+	return count, ""
+}
+
+func (b *ticketBuyer) history() []TicketBuyerDecision {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	decisions := make([]TicketBuyerDecision, len(b.decisions))
+	copy(decisions, b.decisions)
+	return decisions
+}
+
+// StartTicketBuyer starts buying tickets whenever a block is attached and
+// config allows it. The passphrase is checked and kept until the buyer is
+// stopped or the app closes. The config is saved for the next session.
+func (wal *Wallet) StartTicketBuyer(config TicketBuyerConfig, passphrase []byte) error {
+	wal.ticketBuyer.stop()
+	return wal.ticketBuyer.start(config, passphrase)
+}
+
+// StopTicketBuyer stops the ticket buyer and clears its passphrase.
+func (wal *Wallet) StopTicketBuyer() {
+	wal.ticketBuyer.stop()
+}
+
+// TicketBuyerRunning returns true if the ticket buyer is running.
+func (wal *Wallet) TicketBuyerRunning() bool {
+	wal.ticketBuyer.mu.Lock()
+	defer wal.ticketBuyer.mu.Unlock()
+	return wal.ticketBuyer.running
+}
+
+// TicketBuyerConfig returns the config of the ticket buyer, or the config
+// saved in a previous session if it has not been started.
+func (wal *Wallet) TicketBuyerConfig() TicketBuyerConfig {
+	wal.ticketBuyer.mu.Lock()
+	defer wal.ticketBuyer.mu.Unlock()
+
+	if wal.ticketBuyer.running || wal.multi == nil {
+		return wal.ticketBuyer.config
+	}
+
+	var config TicketBuyerConfig
+	_ = wal.multi.ReadUserConfigValue(ticketBuyerConfigKey, &config)
+	return config
+}
+
+// TicketBuyerHistory returns the decisions of the ticket buyer since the app
+// started, oldest first.
+func (wal *Wallet) TicketBuyerHistory() []TicketBuyerDecision {
+	return wal.ticketBuyer.history()
+}
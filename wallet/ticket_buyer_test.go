@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"testing"
+)
+
+const testTicketPrice = 100e8
+
+func TestTicketsToBuy(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    TicketBuyerConfig
+		spendable int64
+		bought    int32
+		want      int64
+	}{{
+		name:      "any price",
+		config:    TicketBuyerConfig{},
+		spendable: 350e8,
+		want:      3,
+	}, {
+		name:      "price above the maximum",
+		config:    TicketBuyerConfig{MaxPrice: 99e8},
+		spendable: 350e8,
+		want:      0,
+	}, {
+		name:      "price at the maximum",
+		config:    TicketBuyerConfig{MaxPrice: testTicketPrice},
+		spendable: 350e8,
+		want:      3,
+	}, {
+		name:      "balance to maintain",
+		config:    TicketBuyerConfig{BalanceToMaintain: 200e8},
+		spendable: 350e8,
+		want:      1,
+	}, {
+		name:      "balance below the balance to maintain",
+		config:    TicketBuyerConfig{BalanceToMaintain: 400e8},
+		spendable: 350e8,
+		want:      0,
+	}, {
+		name:      "VSP fee",
+		config:    TicketBuyerConfig{VSPFeePercentage: 10},
+		spendable: 329e8,
+		want:      2,
+	}, {
+		name:      "limited by the window",
+		config:    TicketBuyerConfig{MaxPerWindow: 5},
+		spendable: 1000e8,
+		bought:    3,
+		want:      2,
+	}, {
+		name:      "window limit reached",
+		config:    TicketBuyerConfig{MaxPerWindow: 5},
+		spendable: 1000e8,
+		bought:    5,
+		want:      0,
+	}}
+
+	for _, test := range tests {
+		b := &ticketBuyer{windowSize: 144, window: 1, boughtInWindow: test.bought}
+		count, reason := b.ticketsToBuy(test.config, 200, testTicketPrice, test.spendable)
+		if count != test.want {
+			t.Errorf("%s: got %d tickets (%s), want %d", test.name, count, reason, test.want)
+		}
+		if count == 0 && reason == "" {
+			t.Errorf("%s: no reason given for not buying", test.name)
+		}
+	}
+}
+
+func TestTicketsToBuyWindow(t *testing.T) {
+	b := &ticketBuyer{windowSize: 144, window: -1}
+	config := TicketBuyerConfig{MaxPerWindow: 2}
+
+	// the count is kept within a window and reset in the next one
+	if count, _ := b.ticketsToBuy(config, 150, testTicketPrice, 1000e8); count != 2 {
+		t.Fatalf("got %d tickets, want 2", count)
+	}
+	if b.window != 1 {
+		t.Fatalf("got window %d, want 1", b.window)
+	}
+	b.boughtInWindow = 2
+
+	if count, _ := b.ticketsToBuy(config, 287, testTicketPrice, 1000e8); count != 0 {
+		t.Fatalf("got %d tickets in a full window, want 0", count)
+	}
+	if count, _ := b.ticketsToBuy(config, 288, testTicketPrice, 1000e8); count != 2 {
+		t.Fatalf("got %d tickets in a new window, want 2", count)
+	}
+	if b.window != 2 || b.boughtInWindow != 0 {
+		t.Fatalf("got window %d with %d bought, want window 2 with none", b.window, b.boughtInWindow)
+	}
+}
+
+func TestTicketBuyerRecord(t *testing.T) {
+	var b ticketBuyer
+
+	// consecutive decisions without purchases are merged
+	b.record(1, 0, "waiting for the wallets to sync", false)
+	b.record(2, 0, "waiting for the wallets to sync", false)
+	b.record(3, 0, "waiting for the wallets to sync", true)
+	b.record(4, 2, "bought 2 ticket(s)", false)
+	b.record(5, 2, "bought 2 ticket(s)", false)
+
+	history := b.history()
+	heights := []int32{2, 3, 4, 5}
+	if len(history) != len(heights) {
+		t.Fatalf("got %d decisions, want %d: %+v", len(history), len(heights), history)
+	}
+	for i, height := range heights {
+		if history[i].Height != height {
+			t.Errorf("decision %d: got height %d, want %d", i, history[i].Height, height)
+		}
+	}
+
+	for i := 0; i < maxTicketBuyerDecisions; i++ {
+		b.record(int32(10+i), 1, "bought 1 ticket(s)", false)
+	}
+	history = b.history()
+	if len(history) != maxTicketBuyerDecisions || history[0].Height != 10 {
+		t.Fatalf("got %d decisions from height %d, want %d from height 10", len(history),
+			history[0].Height, maxTicketBuyerDecisions)
+	}
+}
@@ -0,0 +1,91 @@
+package wallet
+
+import (
+	"sync"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// ticketPurchasesConfigKey is the user config key the ticket purchases
+	// are saved under.
+	ticketPurchasesConfigKey = "godcr_ticket_purchases"
+
+	// maxTicketPurchases is the number of ticket purchases kept.
+	maxTicketPurchases = 1000
+
+	// ticketPurchaseMargin is the time before the start and after the end
+	// of a purchase a ticket can be received in and still be matched to it.
+	ticketPurchaseMargin = time.Minute
+)
+
+// TicketPurchase is a ticket purchase made through the app. dcrlibwallet
+// does not keep the VSP a ticket was bought through, so purchases are
+// recorded to match tickets to their VSP by wallet and time received.
+type TicketPurchase struct {
+	WalletID      int
+	VSPHost       string
+	FeePercentage float64
+	Count         int32
+	Start         time.Time
+	End           time.Time
+}
+
+// Matches returns true if a ticket of walletID received at t was bought by
+// the purchase.
+func (p TicketPurchase) Matches(walletID int, t time.Time) bool {
+	return p.WalletID == walletID &&
+		!t.Before(p.Start.Add(-ticketPurchaseMargin)) &&
+		!t.After(p.End.Add(ticketPurchaseMargin))
+}
+
+// ticketPurchases saves the ticket purchases in the multiwallet user config.
+type ticketPurchases struct {
+	mu    sync.Mutex
+	multi *dcrlibwallet.MultiWallet
+}
+
+func (t *ticketPurchases) list() []TicketPurchase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.read()
+}
+
+func (t *ticketPurchases) read() []TicketPurchase {
+	var purchases []TicketPurchase
+	if t.multi == nil {
+		return purchases
+	}
+
+	// errors other than a missing key are logged by dcrlibwallet
+	_ = t.multi.ReadUserConfigValue(ticketPurchasesConfigKey, &purchases)
+	return purchases
+}
+
+func (t *ticketPurchases) add(purchase TicketPurchase) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.multi == nil {
+		return
+	}
+
+	purchases := append(t.read(), purchase)
+	if len(purchases) > maxTicketPurchases {
+		purchases = purchases[len(purchases)-maxTicketPurchases:]
+	}
+	t.multi.SaveUserConfigValue(ticketPurchasesConfigKey, purchases)
+}
+
+// RecordTicketPurchase saves a ticket purchase so its tickets can be
+// matched to the VSP they were bought through.
+func (wal *Wallet) RecordTicketPurchase(purchase TicketPurchase) {
+	wal.ticketPurchases.add(purchase)
+}
+
+// TicketPurchases returns the ticket purchases made through the app, oldest
+// first.
+func (wal *Wallet) TicketPurchases() []TicketPurchase {
+	return wal.ticketPurchases.list()
+}
@@ -0,0 +1,158 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil/v3"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// TxExportCSV writes exported transactions as comma separated values.
+	TxExportCSV = "csv"
+
+	// TxExportJSON writes exported transactions as a JSON array.
+	TxExportJSON = "json"
+)
+
+var txExportCSVHeader = []string{
+	"wallet", "hash", "type", "direction", "amount", "fee", "block_height", "timestamp",
+	"source_accounts", "destination_accounts", "fiat_value", "fiat_currency",
+}
+
+// TxExportRecord is a single transaction as written by ExportTransactions.
+// Amounts are in DCR. FiatValue is the value of Amount at the exchange rate
+// used for the export and is zero if no rate was available.
+type TxExportRecord struct {
+	Wallet              string    `json:"wallet"`
+	Hash                string    `json:"hash"`
+	Type                string    `json:"type"`
+	Direction           string    `json:"direction"`
+	Amount              float64   `json:"amount"`
+	Fee                 float64   `json:"fee"`
+	BlockHeight         int32     `json:"block_height"`
+	Timestamp           time.Time `json:"timestamp"`
+	SourceAccounts      []string  `json:"source_accounts"`
+	DestinationAccounts []string  `json:"destination_accounts"`
+	FiatValue           float64   `json:"fiat_value,omitempty"`
+	FiatCurrency        string    `json:"fiat_currency,omitempty"`
+}
+
+// TxDirectionName returns a readable name for a dcrlibwallet transaction
+// direction.
+func TxDirectionName(direction int32) string {
+	switch direction {
+	case dcrlibwallet.TxDirectionSent:
+		return "sent"
+	case dcrlibwallet.TxDirectionReceived:
+		return "received"
+	case dcrlibwallet.TxDirectionTransferred:
+		return "transferred"
+	default:
+		return "unknown"
+	}
+}
+
+// NewTxExportRecord returns the export record of tx, which belongs to wal.
+// If rate is not nil it is used to compute the fiat value of the transaction.
+func NewTxExportRecord(wal *dcrlibwallet.Wallet, tx dcrlibwallet.Transaction, rate *ExchangeRate) TxExportRecord {
+	record := TxExportRecord{
+		Wallet:      wal.Name,
+		Hash:        tx.Hash,
+		Type:        tx.Type,
+		Direction:   TxDirectionName(tx.Direction),
+		Amount:      dcrutil.Amount(tx.Amount).ToCoin(),
+		Fee:         dcrutil.Amount(tx.Fee).ToCoin(),
+		BlockHeight: tx.BlockHeight,
+		Timestamp:   time.Unix(tx.Timestamp, 0).UTC(),
+	}
+
+	seen := make(map[int32]bool)
+	for _, input := range tx.Inputs {
+		if input.AccountNumber != -1 && !seen[input.AccountNumber] {
+			seen[input.AccountNumber] = true
+			record.SourceAccounts = append(record.SourceAccounts, accountName(wal, input.AccountNumber))
+		}
+	}
+
+	seen = make(map[int32]bool)
+	for _, output := range tx.Outputs {
+		if output.AccountNumber != -1 && !seen[output.AccountNumber] {
+			seen[output.AccountNumber] = true
+			record.DestinationAccounts = append(record.DestinationAccounts, accountName(wal, output.AccountNumber))
+		}
+	}
+
+	if rate != nil && rate.Rate > 0 {
+		record.FiatValue = record.Amount * rate.Rate
+		record.FiatCurrency = rate.Currency
+	}
+
+	return record
+}
+
+func accountName(wal *dcrlibwallet.Wallet, number int32) string {
+	name, err := wal.AccountName(number)
+	if err != nil {
+		log.Warnf("Could not get name of account %d in wallet %s: %v", number, wal.Name, err)
+		return strconv.Itoa(int(number))
+	}
+	return name
+}
+
+// ExportTransactions writes records to w in the given format, one of
+// TxExportCSV or TxExportJSON.
+func ExportTransactions(w io.Writer, format string, records []TxExportRecord) error {
+	switch format {
+	case TxExportCSV:
+		return exportTransactionsCSV(w, records)
+	case TxExportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+func exportTransactionsCSV(w io.Writer, records []TxExportRecord) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write(txExportCSVHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		fiatValue := ""
+		if r.FiatCurrency != "" {
+			fiatValue = strconv.FormatFloat(r.FiatValue, 'f', 2, 64)
+		}
+
+		err = cw.Write([]string{
+			r.Wallet,
+			r.Hash,
+			r.Type,
+			r.Direction,
+			strconv.FormatFloat(r.Amount, 'f', -1, 64),
+			strconv.FormatFloat(r.Fee, 'f', -1, 64),
+			strconv.Itoa(int(r.BlockHeight)),
+			r.Timestamp.Format(time.RFC3339),
+			strings.Join(r.SourceAccounts, ";"),
+			strings.Join(r.DestinationAccounts, ";"),
+			fiatValue,
+			r.FiatCurrency,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
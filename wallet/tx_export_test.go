@@ -0,0 +1,160 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+var testExportRecords = []TxExportRecord{
+	{
+		Wallet:              "default",
+		Hash:                "aa",
+		Type:                dcrlibwallet.TxTypeRegular,
+		Direction:           "sent",
+		Amount:              12.5,
+		Fee:                 0.0000253,
+		BlockHeight:         1200,
+		Timestamp:           time.Date(2021, 9, 1, 10, 30, 0, 0, time.UTC),
+		SourceAccounts:      []string{"default", "savings"},
+		DestinationAccounts: []string{"default"},
+		FiatValue:           312.5,
+		FiatCurrency:        "USD",
+	},
+	{
+		Wallet:      "default, \"cold\"",
+		Hash:        "bb",
+		Type:        dcrlibwallet.TxTypeVote,
+		Direction:   "received",
+		Amount:      0.1,
+		BlockHeight: -1,
+		Timestamp:   time.Date(2021, 9, 2, 8, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestExportTransactionsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportTransactions(&buf, TxExportCSV, testExportRecords); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		txExportCSVHeader,
+		{"default", "aa", "Regular", "sent", "12.5", "0.0000253", "1200", "2021-09-01T10:30:00Z", "default;savings", "default", "312.50", "USD"},
+		{"default, \"cold\"", "bb", "Vote", "received", "0.1", "0", "-1", "2021-09-02T08:00:00Z", "", "", "", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("got\n%q\nwant\n%q", rows, want)
+	}
+
+	buf.Reset()
+	if err := ExportTransactions(&buf, TxExportCSV, nil); err != nil {
+		t.Fatal(err)
+	}
+	rows, _ = csv.NewReader(&buf).ReadAll()
+	if !reflect.DeepEqual(rows, [][]string{txExportCSVHeader}) {
+		t.Fatalf("got %q, want only the header for no transactions", rows)
+	}
+}
+
+func TestExportTransactionsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportTransactions(&buf, TxExportJSON, testExportRecords); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []TxExportRecord
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(records, testExportRecords) {
+		t.Fatalf("got %+v, want %+v", records, testExportRecords)
+	}
+
+	// the fiat value is left out without a rate
+	var fields []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields[0]["fiat_currency"]; !ok {
+		t.Errorf("got no fiat currency in %v", fields[0])
+	}
+	if _, ok := fields[1]["fiat_value"]; ok {
+		t.Errorf("got a fiat value in %v", fields[1])
+	}
+
+	if err := ExportTransactions(&buf, "xml", testExportRecords); err == nil {
+		t.Errorf("expected an error exporting an unknown format")
+	}
+}
+
+func TestNewTxExportRecord(t *testing.T) {
+	multi, err := dcrlibwallet.NewMultiWallet(t.TempDir(), "bdb", "testnet3", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer multi.Shutdown()
+
+	wal, err := multi.CreateNewWallet("savings", "password", dcrlibwallet.PassphraseTypePass)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := dcrlibwallet.Transaction{
+		Hash:        "aa",
+		Type:        dcrlibwallet.TxTypeRegular,
+		Direction:   dcrlibwallet.TxDirectionTransferred,
+		Amount:      250000000,
+		Fee:         2530,
+		BlockHeight: 1200,
+		Timestamp:   time.Date(2021, 9, 1, 10, 30, 0, 0, time.UTC).Unix(),
+		Inputs: []*dcrlibwallet.TxInput{
+			{AccountNumber: dcrlibwallet.DefaultAccountNum},
+			{AccountNumber: dcrlibwallet.DefaultAccountNum},
+			{AccountNumber: 7}, // not an account of the wallet
+		},
+		Outputs: []*dcrlibwallet.TxOutput{
+			{AccountNumber: -1},
+			{AccountNumber: dcrlibwallet.DefaultAccountNum},
+		},
+	}
+
+	record := NewTxExportRecord(wal, tx, &ExchangeRate{Currency: "EUR", Rate: 20})
+	want := TxExportRecord{
+		Wallet:              "savings",
+		Hash:                "aa",
+		Type:                dcrlibwallet.TxTypeRegular,
+		Direction:           "transferred",
+		Amount:              2.5,
+		Fee:                 0.0000253,
+		BlockHeight:         1200,
+		Timestamp:           time.Date(2021, 9, 1, 10, 30, 0, 0, time.UTC),
+		SourceAccounts:      []string{"default", "7"},
+		DestinationAccounts: []string{"default"},
+		FiatValue:           50,
+		FiatCurrency:        "EUR",
+	}
+	if math.Abs(record.FiatValue-want.FiatValue) < 1e-9 {
+		record.FiatValue = want.FiatValue
+	}
+	if !reflect.DeepEqual(record, want) {
+		t.Fatalf("got %+v, want %+v", record, want)
+	}
+
+	for _, rate := range []*ExchangeRate{nil, {Currency: "EUR"}} {
+		record = NewTxExportRecord(wal, tx, rate)
+		if record.FiatValue != 0 || record.FiatCurrency != "" {
+			t.Errorf("got a fiat value of %v %s with rate %v", record.FiatValue, record.FiatCurrency, rate)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// AnyAccount matches transactions of every account in a TxSearch.
+	AnyAccount int32 = -1
+
+	// txIndexPageSize is the number of transactions read at a time while
+	// the index is built.
+	txIndexPageSize = 500
+)
+
+// TxSearch is a transaction search. Zero fields do not restrict the search,
+// except Account which is AnyAccount for every account.
+type TxSearch struct {
+	// Text is matched against the transaction hash, the addresses of its
+	// inputs and outputs and its label and note, ignoring case.
+	Text string
+
+	WalletID int
+	Account  int32
+
+	// MinAmount and MaxAmount bound the amount of the transaction in atoms.
+	MinAmount int64
+	MaxAmount int64
+
+	From time.Time
+	To   time.Time
+}
+
+// TxSearchResult is a transaction matching a TxSearch.
+type TxSearchResult struct {
+	WalletID  int
+	Hash      string
+	Type      string
+	Direction int32
+	Amount    int64
+	Timestamp int64
+}
+
+// txIndexEntry is what the index keeps of a transaction to search it.
+type txIndexEntry struct {
+	result   TxSearchResult
+	accounts []int32
+
+	// text is the lower case hash and addresses of the transaction.
+	text string
+}
+
+func (e *txIndexEntry) matches(search TxSearch, text string, labels *labelStore) bool {
+	if search.WalletID != 0 && e.result.WalletID != search.WalletID {
+		return false
+	}
+	if search.MinAmount > 0 && e.result.Amount < search.MinAmount {
+		return false
+	}
+	if search.MaxAmount > 0 && e.result.Amount > search.MaxAmount {
+		return false
+	}
+	if !search.From.IsZero() && e.result.Timestamp < search.From.Unix() {
+		return false
+	}
+	if !search.To.IsZero() && e.result.Timestamp > search.To.Unix() {
+		return false
+	}
+	if search.Account != AnyAccount {
+		found := false
+		for _, account := range e.accounts {
+			if account == search.Account {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if text == "" || strings.Contains(e.text, text) {
+		return true
+	}
+	// labels are not indexed as they can be edited at any time.
+	return labels != nil && labels.matches(e.result.WalletID, e.result.Hash, text)
+}
+
+// txIndex keeps the searchable fields of the transactions of every wallet in
+// memory. It is built in the background when the listeners are set up and
+// rebuilt when a sync completes, and new transactions are added as they are
+// received.
+type txIndex struct {
+	mu       sync.Mutex
+	multi    *dcrlibwallet.MultiWallet
+	labels   *labelStore
+	entries  map[string]*txIndexEntry
+	building bool
+	rebuild  bool
+}
+
+func txIndexKey(walletID int, hash string) string {
+	return fmt.Sprintf("%d:%s", walletID, hash)
+}
+
+// build indexes every transaction of the opened wallets. A build requested
+// while one is running starts again once it is done, so transactions found
+// meanwhile are included.
+func (idx *txIndex) build() {
+	idx.mu.Lock()
+	if idx.multi == nil {
+		idx.mu.Unlock()
+		return
+	}
+	if idx.building {
+		idx.rebuild = true
+		idx.mu.Unlock()
+		return
+	}
+	idx.building = true
+	idx.mu.Unlock()
+
+	go func() {
+		for {
+			start := time.Now()
+			entries, err := idx.read()
+			if err != nil {
+				log.Errorf("Error building the transaction index: %v", err)
+			}
+
+			idx.mu.Lock()
+			if err == nil {
+				idx.entries = entries
+				log.Infof("Indexed %d transactions in %s", len(entries), time.Since(start).Round(time.Millisecond))
+			}
+			if !idx.rebuild {
+				idx.building = false
+				idx.mu.Unlock()
+				return
+			}
+			idx.rebuild = false
+			idx.mu.Unlock()
+		}
+	}()
+}
+
+func (idx *txIndex) read() (map[string]*txIndexEntry, error) {
+	entries := make(map[string]*txIndexEntry)
+	for _, id := range idx.multi.OpenedWalletIDsRaw() {
+		w := idx.multi.WalletWithID(id)
+		if w == nil {
+			continue
+		}
+
+		var txs []dcrlibwallet.Transaction
+		for offset := int32(0); ; offset += txIndexPageSize {
+			page, err := w.GetTransactionsRaw(offset, txIndexPageSize, dcrlibwallet.TxFilterAll, true)
+			if err != nil {
+				return nil, err
+			}
+			txs = append(txs, page...)
+			if len(page) < txIndexPageSize {
+				break
+			}
+		}
+
+		// inputs only reference the output they spend, so their address
+		// is found from the outputs of the wallet's transactions.
+		outputs := make(map[string]string)
+		for _, tx := range txs {
+			for _, output := range tx.Outputs {
+				outputs[fmt.Sprintf("%s:%d", tx.Hash, output.Index)] = output.Address
+			}
+		}
+
+		for i := range txs {
+			entry := newTxIndexEntry(&txs[i], outputs)
+			entries[txIndexKey(entry.result.WalletID, entry.result.Hash)] = entry
+		}
+	}
+	return entries, nil
+}
+
+func newTxIndexEntry(tx *dcrlibwallet.Transaction, outputs map[string]string) *txIndexEntry {
+	entry := &txIndexEntry{
+		result: TxSearchResult{
+			WalletID:  tx.WalletID,
+			Hash:      tx.Hash,
+			Type:      tx.Type,
+			Direction: tx.Direction,
+			Amount:    tx.Amount,
+			Timestamp: tx.Timestamp,
+		},
+	}
+
+	text := []string{tx.Hash}
+	seen := make(map[int32]bool)
+	addAccount := func(account int32) {
+		if account != -1 && !seen[account] {
+			seen[account] = true
+			entry.accounts = append(entry.accounts, account)
+		}
+	}
+	for _, input := range tx.Inputs {
+		addAccount(input.AccountNumber)
+		if address := outputs[input.PreviousOutpoint]; address != "" {
+			text = append(text, address)
+		}
+	}
+	for _, output := range tx.Outputs {
+		addAccount(output.AccountNumber)
+		if output.Address != "" {
+			text = append(text, output.Address)
+		}
+	}
+	entry.text = strings.ToLower(strings.Join(text, " "))
+
+	return entry
+}
+
+// add indexes a new transaction. The address of its inputs is looked up in
+// the transactions of its wallet.
+func (idx *txIndex) add(tx *dcrlibwallet.Transaction) {
+	if idx.multi == nil {
+		return
+	}
+	w := idx.multi.WalletWithID(tx.WalletID)
+	if w == nil {
+		return
+	}
+
+	outputs := make(map[string]string)
+	for _, input := range tx.Inputs {
+		prev, err := w.GetTransactionRaw(input.PreviousTransactionHash)
+		if err != nil {
+			continue
+		}
+		for _, output := range prev.Outputs {
+			if output.Index == input.PreviousTransactionIndex {
+				outputs[input.PreviousOutpoint] = output.Address
+			}
+		}
+	}
+	entry := newTxIndexEntry(tx, outputs)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.entries != nil {
+		idx.entries[txIndexKey(entry.result.WalletID, entry.result.Hash)] = entry
+	}
+}
+
+func (idx *txIndex) search(search TxSearch, limit int) []TxSearchResult {
+	text := strings.ToLower(strings.TrimSpace(search.Text))
+
+	idx.mu.Lock()
+	var results []TxSearchResult
+	for _, entry := range idx.entries {
+		if entry.matches(search, text, idx.labels) {
+			results = append(results, entry.result)
+		}
+	}
+	idx.mu.Unlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Timestamp > results[j].Timestamp
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// SearchTransactions returns the transactions of all opened wallets matching
+// search, newest first. At most limit results are returned if limit is more
+// than zero. Transactions are searched in an index that is built in the
+// background, so results are incomplete until TxIndexReady returns true.
+func (wal *Wallet) SearchTransactions(search TxSearch, limit int) []TxSearchResult {
+	return wal.txIndex.search(search, limit)
+}
+
+// TxIndexReady returns true once the transaction index has been built.
+func (wal *Wallet) TxIndexReady() bool {
+	wal.txIndex.mu.Lock()
+	defer wal.txIndex.mu.Unlock()
+	return wal.txIndex.entries != nil
+}
+
+// IndexedTransactions returns the number of transactions in the transaction
+// index.
+func (wal *Wallet) IndexedTransactions() int {
+	wal.txIndex.mu.Lock()
+	defer wal.txIndex.mu.Unlock()
+	return len(wal.txIndex.entries)
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTxIndexEntryMatches(t *testing.T) {
+	at := time.Date(2021, 9, 15, 12, 0, 0, 0, time.UTC)
+	entry := &txIndexEntry{
+		result: TxSearchResult{
+			WalletID:  2,
+			Hash:      "abc123",
+			Amount:    5e8,
+			Timestamp: at.Unix(),
+		},
+		accounts: []int32{0, 3},
+		text:     "abc123 tsfdlrrkk9ciuuwfp2b8pawwnukyd7yajgd",
+	}
+
+	labels := &labelStore{wallets: testLabelWallets{
+		2: {{Type: LabelTx, Ref: "abc123", Label: "Rent", Note: "March payment"}},
+	}}
+
+	tests := []struct {
+		name   string
+		search TxSearch
+		want   bool
+	}{
+		{"any transaction", TxSearch{Account: AnyAccount}, true},
+		{"wallet", TxSearch{WalletID: 2, Account: AnyAccount}, true},
+		{"other wallet", TxSearch{WalletID: 1, Account: AnyAccount}, false},
+		{"account", TxSearch{Account: 3}, true},
+		{"other account", TxSearch{Account: 1}, false},
+		{"amount in range", TxSearch{Account: AnyAccount, MinAmount: 5e8, MaxAmount: 5e8}, true},
+		{"amount below the minimum", TxSearch{Account: AnyAccount, MinAmount: 6e8}, false},
+		{"amount above the maximum", TxSearch{Account: AnyAccount, MaxAmount: 4e8}, false},
+		{"in date range", TxSearch{Account: AnyAccount, From: at, To: at}, true},
+		{"before the date range", TxSearch{Account: AnyAccount, From: at.Add(time.Second)}, false},
+		{"after the date range", TxSearch{Account: AnyAccount, To: at.Add(-time.Second)}, false},
+		{"hash", TxSearch{Account: AnyAccount, Text: "c12"}, true},
+		{"address", TxSearch{Account: AnyAccount, Text: "tsfdlr"}, true},
+		{"label", TxSearch{Account: AnyAccount, Text: "rent"}, true},
+		{"note", TxSearch{Account: AnyAccount, Text: "march"}, true},
+		{"text not found", TxSearch{Account: AnyAccount, Text: "april"}, false},
+		{"text found with other fields not matching", TxSearch{Account: 1, Text: "rent"}, false},
+	}
+
+	for _, test := range tests {
+		// the text is matched in lower case
+		if got := entry.matches(test.search, test.search.Text, labels); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	// without labels only the indexed text is searched
+	if entry.matches(TxSearch{Account: AnyAccount}, "rent", nil) {
+		t.Errorf("label matched without labels")
+	}
+}
@@ -1,6 +1,10 @@
 package wallet
 
-import "github.com/planetdecred/dcrlibwallet"
+import (
+	"encoding/json"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
 
 // NewBlock is sent when a block is attached to the multiwallet.
 type NewBlock struct {
@@ -20,26 +24,39 @@ type NewTransaction struct {
 }
 
 func (l *listener) OnTransaction(transaction string) {
-	l.Send <- SyncStatusUpdate{}
+	var tx dcrlibwallet.Transaction
+	if err := json.Unmarshal([]byte(transaction), &tx); err != nil {
+		log.Error(err)
+		return
+	}
+	if l.index != nil {
+		l.index.add(&tx)
+	}
+	l.events.PublishTransaction(NewTransaction{Transaction: &tx})
 }
 
 func (l *listener) OnBlockAttached(walletID int, blockHeight int32) {
-	l.Send <- SyncStatusUpdate{
-		Stage: BlockAttached,
-		BlockInfo: NewBlock{
-			WalletID: walletID,
-			Height:   blockHeight,
-		},
+	if l.buyer != nil {
+		l.buyer.blockAttached(walletID, blockHeight)
 	}
+	block := NewBlock{
+		WalletID: walletID,
+		Height:   blockHeight,
+	}
+	l.events.PublishBlock(block)
+	l.events.PublishSync(SyncStatusUpdate{
+		Stage:     BlockAttached,
+		BlockInfo: block,
+	})
 }
 
 func (l *listener) OnTransactionConfirmed(walletID int, hash string, blockHeight int32) {
-	l.Send <- SyncStatusUpdate{
+	l.events.PublishSync(SyncStatusUpdate{
 		Stage: BlockConfirmed,
 		ConfirmedTxn: TxConfirmed{
 			WalletID: walletID,
 			Height:   blockHeight,
 			Hash:     hash,
 		},
-	}
+	})
 }
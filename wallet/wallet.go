@@ -3,13 +3,13 @@
 package wallet
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"path/filepath"
 	"sort"
 	"time"
 
 	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/dcrlibwallet/utils"
 )
 
 const (
@@ -25,29 +25,53 @@ type Wallet struct {
 	buildDate          time.Time
 	version            string
 	logFile            string
-	Send               chan Response
-	Sync               chan SyncStatusUpdate
+	events             *EventBus
 	OverallBlockHeight int32
 	startUpTime        time.Time
+	rates              *RateCache
+	network            NetworkParams
+	explorer           BlockExplorer
+	logLevels          LogLevels
+	syncHistory        syncHistory
+	ticketBuyer        ticketBuyer
+	ticketPurchases    ticketPurchases
+	txIndex            txIndex
+	labels             labelStore
+	configSummary      string
+}
+
+// LogLevels reads and changes the debug levels of the app's log
+// subsystems while the app is running.
+type LogLevels interface {
+	Subsystems() []string
+	Level(subsystem string) string
+	SetLevel(subsystem, level string) error
 }
 
 // NewWallet initializies an new Wallet instance.
 // The Wallet is not loaded until LoadWallets is called.
-func NewWallet(root, net, version, logFile string, buildDate time.Time, send chan Response) (*Wallet, error) {
+func NewWallet(root, net, version, logFile string, buildDate time.Time) (*Wallet, error) {
 	if root == "" || net == "" { // This should really be handled by dcrlibwallet
 		return nil, fmt.Errorf(`root directory or network cannot be ""`)
 	}
 
+	network, ok := Network(net)
+	if !ok {
+		return nil, fmt.Errorf("unknown network %q", net)
+	}
+
 	wal := &Wallet{
 		Root:        root,
 		Net:         net,
 		buildDate:   buildDate,
 		version:     version,
 		logFile:     logFile,
-		Sync:        make(chan SyncStatusUpdate, 2),
-		Send:        send,
+		events:      NewEventBus(),
 		startUpTime: time.Now(),
+		network:     network,
+		explorer:    network.BlockExplorer,
 	}
+	wal.syncHistory.load(filepath.Join(root, net, syncSessionsFile))
 
 	return wal, nil
 }
@@ -69,51 +93,60 @@ func (wal *Wallet) StartupTime() time.Time {
 }
 
 func (wal *Wallet) InitMultiWallet() error {
-	politeiaHost := dcrlibwallet.PoliteiaMainnetHost
-	if wal.Net == dcrlibwallet.Testnet3 {
-		politeiaHost = dcrlibwallet.PoliteiaTestnetHost
-	}
-	multiWal, err := dcrlibwallet.NewMultiWallet(wal.Root, "bdb", wal.Net, politeiaHost)
+	multiWal, err := dcrlibwallet.NewMultiWallet(wal.Root, "bdb", wal.Net, wal.network.PoliteiaHost)
 	if err != nil {
 		return err
 	}
 
+	// Networks without public peers sync from a local node unless another
+	// peer is set in the settings.
+	peerKey := dcrlibwallet.SpvPersistentPeerAddressesConfigKey
+	if wal.network.DefaultPeer != "" && multiWal.ReadStringConfigValueForKey(peerKey) == "" {
+		multiWal.SetStringConfigValueForKey(peerKey, wal.network.DefaultPeer)
+	}
+
 	wal.multi = multiWal
+	wal.ticketBuyer.multi = multiWal
+	wal.ticketBuyer.purchases = &wal.ticketPurchases
+	wal.ticketPurchases.multi = multiWal
+	wal.txIndex.multi = multiWal
+	wal.txIndex.labels = &wal.labels
+	wal.labels.wallets = multiLabelWallets{multiWal}
+	if params, err := utils.ChainParams(wal.Net); err == nil {
+		wal.ticketBuyer.windowSize = params.StakeDiffWindowSize
+	}
 	return nil
 }
 
-func (wal *Wallet) SetupListeners() {
-	resp := Response{
-		Resp: LoadedWallets{},
-	}
+// SetupListeners adds the listener that publishes the multiwallet
+// notifications to the event bus, keeps the sync history and transaction
+// index up to date and runs the ticket buyer. It starts building the
+// transaction index.
+func (wal *Wallet) SetupListeners() error {
 	l := &listener{
-		Send: wal.Sync,
+		events:  wal.events,
+		history: &wal.syncHistory,
+		buyer:   &wal.ticketBuyer,
+		index:   &wal.txIndex,
 	}
 	err := wal.multi.AddSyncProgressListener(l, syncID)
 	if err != nil {
-		resp.Err = err
-		wal.Send <- resp
-		return
+		return err
 	}
 
 	err = wal.multi.AddTxAndBlockNotificationListener(l, syncID)
 	if err != nil {
-		resp.Err = err
-		wal.Send <- resp
-		return
+		return err
 	}
 
 	wal.multi.AddAccountMixerNotificationListener(l, syncID)
 
 	wal.multi.Politeia.AddNotificationListener(l, syncID)
 
-	startupPassSet := wal.multi.IsStartupSecuritySet()
+	wal.multi.SetBlocksRescanProgressListener(l)
 
-	resp.Resp = LoadedWallets{
-		Count:              wal.multi.LoadedWalletsCount(),
-		StartUpSecuritySet: startupPassSet,
-	}
-	wal.Send <- resp
+	wal.txIndex.build()
+	return nil
 }
 
 // wallets returns an up-to-date map of all opened wallets
@@ -148,46 +181,50 @@ func (wal *Wallet) wallets() ([]dcrlibwallet.Wallet, error) {
 }
 
 func (wal *Wallet) hdPrefix() string {
-	switch wal.Net {
-	case "testnet3": // should use a constant
-		return dcrlibwallet.TestnetHDPath
-	case "mainnet":
-		return dcrlibwallet.MainnetHDPath
-	default:
-		return ""
-	}
+	return wal.network.HDPath
 }
 
 // Shutdown shutsdown the multiwallet
 func (wal *Wallet) Shutdown() {
+	wal.ticketBuyer.stop()
 	if wal.multi != nil {
 		wal.multi.Shutdown()
 	}
 }
 
-// GetBlockExplorerURL accept transaction hash,
-// return the block explorer URL with respect to the network
-func (wal *Wallet) GetBlockExplorerURL(txnHash string) string {
-	switch wal.Net {
-	case "testnet3": // should use a constant
-		return "https://testnet.dcrdata.org/tx/" + txnHash
-	case "mainnet":
-		return "https://explorer.dcrdata.org/tx/" + txnHash
-	default:
-		return ""
-	}
+// SetRateCache sets the cache used to look up exchange rates. No rates are
+// available until it is set.
+func (wal *Wallet) SetRateCache(rates *RateCache) {
+	wal.rates = rates
 }
 
-//GetUSDExchangeValues gets the exchange rate of DCR - USDT from a specified endpoint
-func (wal *Wallet) GetUSDExchangeValues(target interface{}) error {
-	url := "https://api.bittrex.com/v3/markets/DCR-USDT/ticker"
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
+// ExchangeRate returns the price of one DCR in currency from the configured
+// rate sources.
+func (wal *Wallet) ExchangeRate(currency string) (ExchangeRate, error) {
+	if wal.rates == nil {
+		return ExchangeRate{}, fmt.Errorf("no rate source configured")
 	}
+	return wal.rates.Rate(currency)
+}
 
-	defer resp.Body.Close()
+// SetLogLevels sets the log levels that can be changed from the UI.
+func (wal *Wallet) SetLogLevels(levels LogLevels) {
+	wal.logLevels = levels
+}
 
-	json.NewDecoder(resp.Body).Decode(target)
-	return nil
+// LogLevels returns the log levels that can be changed from the UI, or nil
+// if they cannot be changed.
+func (wal *Wallet) LogLevels() LogLevels {
+	return wal.logLevels
+}
+
+// SetConfigSummary sets the app configuration included in diagnostics. It
+// must not contain secrets.
+func (wal *Wallet) SetConfigSummary(summary string) {
+	wal.configSummary = summary
+}
+
+// ConfigSummary returns the app configuration included in diagnostics.
+func (wal *Wallet) ConfigSummary() string {
+	return wal.configSummary
 }